@@ -0,0 +1,499 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	generatedHeader = "// Code generated by streamsgen. DO NOT EDIT."
+	annotation      = "//streams:generate"
+	streamsImport   = "github.com/go-andiamo/streams"
+)
+
+type config struct {
+	dir        string
+	types      []string // types is the names of the struct types to generate for (if empty, annotated structs are used)
+	typePrefix bool
+}
+
+type fieldKind int
+
+const (
+	kindOther   fieldKind = iota // only a key extractor is generated
+	kindOrdered                  // strings, integers and floats - comparable with < and >
+	kindBool
+	kindTime
+)
+
+type genField struct {
+	Name    string
+	Type    string
+	Kind    fieldKind
+	KeyName string
+	ByName  string
+	EqName  string
+	InName  string
+	Between string
+}
+
+func (f genField) Comparable() bool {
+	return f.Kind != kindOther
+}
+
+func (f genField) Ordered() bool {
+	return f.Kind == kindOrdered || f.Kind == kindTime
+}
+
+func (f genField) IsBool() bool {
+	return f.Kind == kindBool
+}
+
+func (f genField) IsTime() bool {
+	return f.Kind == kindTime
+}
+
+type genType struct {
+	Name   string
+	Fields []genField
+}
+
+type genPackage struct {
+	dir       string
+	name      string
+	files     []*ast.File
+	names     []string
+	structs   map[string]*ast.TypeSpec
+	order     []string
+	annotated map[string]bool
+	locals    map[string]*ast.TypeSpec
+	declared  map[string]bool
+}
+
+// generate generates the source for the configured types - returning the source and the name of the first file of the package
+func generate(cfg config) ([]byte, string, error) {
+	pkg, err := loadPackage(cfg.dir)
+	if err != nil {
+		return nil, "", err
+	}
+	names := cfg.types
+	if len(names) == 0 {
+		names = pkg.annotatedStructs()
+		if len(names) == 0 {
+			return nil, "", fmt.Errorf("no types specified (use -type or annotate structs with %s)", annotation)
+		}
+	}
+	imports := map[string]string{"streams": streamsImport}
+	used := map[string]string{}
+	gts := make([]genType, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		ts, ok := pkg.structs[name]
+		if !ok {
+			return nil, "", fmt.Errorf("struct type %s not found in package %s", name, pkg.name)
+		} else if ts.TypeParams != nil && len(ts.TypeParams.List) > 0 {
+			return nil, "", fmt.Errorf("generic struct type %s is not supported", name)
+		}
+		gt, err := pkg.genType(ts, cfg.typePrefix, imports)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, f := range gt.Fields {
+			for _, id := range f.identifiers() {
+				if pkg.declared[id] {
+					return nil, "", fmt.Errorf("generated identifier %s (for %s.%s) is already declared in package %s - use -typeprefix", id, name, f.Name, pkg.name)
+				} else if other, ok := used[id]; ok {
+					return nil, "", fmt.Errorf("generated identifier %s (for %s.%s) clashes with %s - use -typeprefix", id, name, f.Name, other)
+				}
+				used[id] = name + "." + f.Name
+			}
+		}
+		gts = append(gts, gt)
+	}
+	var buf bytes.Buffer
+	if err = sourceTemplate.Execute(&buf, map[string]any{
+		"Header":  generatedHeader,
+		"Package": pkg.name,
+		"Imports": importLines(imports),
+		"Types":   gts,
+	}); err != nil {
+		return nil, "", err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, "", fmt.Errorf("formatting generated source: %w", err)
+	}
+	return src, pkg.names[0], nil
+}
+
+func loadPackage(dir string) (*genPackage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &genPackage{
+		dir:       dir,
+		structs:   map[string]*ast.TypeSpec{},
+		annotated: map[string]bool{},
+		locals:    map[string]*ast.TypeSpec{},
+		declared:  map[string]bool{},
+	}
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		fn := e.Name()
+		if e.IsDir() || !strings.HasSuffix(fn, ".go") || strings.HasSuffix(fn, "_test.go") {
+			continue
+		} else if match, err := build.Default.MatchFile(dir, fn); err != nil {
+			return nil, err
+		} else if !match {
+			// excluded by build constraints (e.g. other platforms or tags)...
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, fn), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if isGenerated(f) {
+			continue
+		} else if pkg.name == "" {
+			pkg.name = f.Name.Name
+		} else if pkg.name != f.Name.Name {
+			return nil, fmt.Errorf("multiple packages in %s (%s and %s)", dir, pkg.name, f.Name.Name)
+		}
+		pkg.files = append(pkg.files, f)
+		pkg.names = append(pkg.names, fn)
+		pkg.collect(f)
+	}
+	if len(pkg.files) == 0 {
+		return nil, fmt.Errorf("no go files found in %s", dir)
+	}
+	return pkg, nil
+}
+
+func isGenerated(f *ast.File) bool {
+	for _, cg := range f.Comments {
+		if cg.Pos() > f.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if c.Text == generatedHeader {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (pkg *genPackage) collect(f *ast.File) {
+	for _, d := range f.Decls {
+		switch decl := d.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				pkg.declared[decl.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					pkg.declared[s.Name.Name] = true
+					pkg.locals[s.Name.Name] = s
+					if _, ok := s.Type.(*ast.StructType); ok {
+						pkg.structs[s.Name.Name] = s
+						pkg.order = append(pkg.order, s.Name.Name)
+						pkg.annotated[s.Name.Name] = isAnnotated(decl.Doc) || isAnnotated(s.Doc)
+					}
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						pkg.declared[n.Name] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+func isAnnotated(doc *ast.CommentGroup) bool {
+	if doc != nil {
+		for _, c := range doc.List {
+			if strings.TrimSpace(c.Text) == annotation {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (pkg *genPackage) annotatedStructs() []string {
+	result := make([]string, 0)
+	for _, name := range pkg.order {
+		if pkg.annotated[name] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func (pkg *genPackage) fileOf(ts *ast.TypeSpec) *ast.File {
+	for _, f := range pkg.files {
+		if f.Pos() <= ts.Pos() && ts.End() <= f.End() {
+			return f
+		}
+	}
+	return nil
+}
+
+func (pkg *genPackage) genType(ts *ast.TypeSpec, typePrefix bool, imports map[string]string) (genType, error) {
+	result := genType{Name: ts.Name.Name}
+	prefix := ""
+	if typePrefix {
+		prefix = ts.Name.Name
+	}
+	file := pkg.fileOf(ts)
+	for _, fld := range ts.Type.(*ast.StructType).Fields.List {
+		if len(fld.Names) == 0 || isSkipped(fld.Tag) {
+			continue
+		}
+		kind := pkg.kindOf(fld.Type, file)
+		for _, n := range fld.Names {
+			if !n.IsExported() {
+				continue
+			} else if err := addImports(fld.Type, file, imports); err != nil {
+				return result, fmt.Errorf("%s.%s: %w", ts.Name.Name, n.Name, err)
+			}
+			result.Fields = append(result.Fields, genField{
+				Name:    n.Name,
+				Type:    types.ExprString(fld.Type),
+				Kind:    kind,
+				KeyName: prefix + n.Name + "Key",
+				ByName:  prefix + "By" + n.Name,
+				EqName:  prefix + n.Name + "Eq",
+				InName:  prefix + n.Name + "In",
+				Between: prefix + n.Name + "Between",
+			})
+		}
+	}
+	if len(result.Fields) == 0 {
+		return result, fmt.Errorf("struct type %s has no exported fields", ts.Name.Name)
+	}
+	return result, nil
+}
+
+func (f genField) identifiers() []string {
+	result := []string{f.KeyName}
+	if f.Comparable() {
+		result = append(result, f.ByName, f.EqName, f.InName)
+	}
+	if f.Ordered() {
+		result = append(result, f.Between)
+	}
+	return result
+}
+
+func isSkipped(tag *ast.BasicLit) bool {
+	if tag != nil {
+		if s, err := strconv.Unquote(tag.Value); err == nil {
+			return reflect.StructTag(s).Get("streams") == "-"
+		}
+	}
+	return false
+}
+
+var basicKinds = map[string]fieldKind{
+	"string": kindOrdered,
+	"int":    kindOrdered, "int8": kindOrdered, "int16": kindOrdered, "int32": kindOrdered, "int64": kindOrdered,
+	"uint": kindOrdered, "uint8": kindOrdered, "uint16": kindOrdered, "uint32": kindOrdered, "uint64": kindOrdered,
+	"uintptr": kindOrdered, "byte": kindOrdered, "rune": kindOrdered,
+	"float32": kindOrdered, "float64": kindOrdered,
+	"bool": kindBool,
+}
+
+// kindOf determines the kind of field type - following locally declared types (e.g. type Status string) to their underlying type
+//
+// only time.Time (or an alias of it) is a time kind - a type defined as time.Time (e.g. type Stamp time.Time) does not have
+// its methods
+func (pkg *genPackage) kindOf(expr ast.Expr, file *ast.File) fieldKind {
+	seen := map[string]bool{}
+	defined := false
+	for {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			if local, ok := pkg.locals[t.Name]; ok && !seen[t.Name] {
+				seen[t.Name] = true
+				defined = defined || !local.Assign.IsValid()
+				expr, file = local.Type, pkg.fileOf(local)
+				continue
+			}
+			return basicKinds[t.Name]
+		case *ast.SelectorExpr:
+			if x, ok := t.X.(*ast.Ident); ok && !defined && t.Sel.Name == "Time" && importPath(file, x.Name) == "time" {
+				return kindTime
+			}
+		case *ast.ParenExpr:
+			expr = t.X
+			continue
+		}
+		return kindOther
+	}
+}
+
+// addImports adds the imports needed for package qualified identifiers in a field type
+func addImports(expr ast.Expr, file *ast.File, imports map[string]string) (err error) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && err == nil {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				p := importPath(file, x.Name)
+				if p == "" {
+					err = fmt.Errorf("unknown package %s", x.Name)
+				} else if existing, ok := imports[x.Name]; ok && existing != p {
+					err = fmt.Errorf("package name %s refers to both %q and %q", x.Name, existing, p)
+				} else {
+					imports[x.Name] = p
+				}
+			}
+			return false
+		}
+		return true
+	})
+	return
+}
+
+// importPath returns the import path for a local package name in a file (or empty string if not imported)
+func importPath(file *ast.File, name string) string {
+	if file != nil {
+		for _, imp := range file.Imports {
+			p, _ := strconv.Unquote(imp.Path.Value)
+			if imp.Name != nil {
+				if imp.Name.Name == name {
+					return p
+				}
+			} else if path.Base(p) == name {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// importLines returns the import lines - standard library imports first, then a blank line, then other imports
+func importLines(imports map[string]string) []string {
+	std, other := make([]string, 0, len(imports)), make([]string, 0, len(imports))
+	for name, p := range imports {
+		line := strconv.Quote(p)
+		if path.Base(p) != name {
+			line = name + " " + line
+		}
+		if first, _, _ := strings.Cut(p, "/"); strings.Contains(first, ".") {
+			other = append(other, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	for _, lines := range [][]string{std, other} {
+		sort.Slice(lines, func(i, j int) bool {
+			return unaliased(lines[i]) < unaliased(lines[j])
+		})
+	}
+	if len(std) > 0 {
+		std = append(std, "")
+	}
+	return append(std, other...)
+}
+
+func unaliased(line string) string {
+	if i := strings.IndexByte(line, '"'); i >= 0 {
+		return line[i:]
+	}
+	return line
+}
+
+var sourceTemplate = template.Must(template.New("source").Parse(`{{.Header}}
+
+package {{.Package}}
+
+import (
+{{range .Imports}}	{{.}}
+{{end}})
+{{range $t := .Types}}{{range .Fields}}
+// {{.KeyName}} is the key extractor for {{$t.Name}}.{{.Name}}
+func {{.KeyName}}(v {{$t.Name}}) {{.Type}} {
+	return v.{{.Name}}
+}
+{{if .Comparable}}
+// {{.ByName}} is the Comparator for {{$t.Name}}.{{.Name}}
+var {{.ByName}} = streams.NewComparator[{{$t.Name}}](func(v1, v2 {{$t.Name}}) int {
+{{- if .IsBool}}
+	if v1.{{.Name}} == v2.{{.Name}} {
+		return 0
+	} else if !v1.{{.Name}} {
+		return -1
+	}
+	return 1
+{{- else if .IsTime}}
+	if v1.{{.Name}}.Before(v2.{{.Name}}) {
+		return -1
+	} else if v1.{{.Name}}.After(v2.{{.Name}}) {
+		return 1
+	}
+	return 0
+{{- else}}
+	if v1.{{.Name}} < v2.{{.Name}} {
+		return -1
+	} else if v1.{{.Name}} > v2.{{.Name}} {
+		return 1
+	}
+	return 0
+{{- end}}
+})
+
+// {{.EqName}} returns a Predicate that tests whether {{$t.Name}}.{{.Name}} equals the specified value
+func {{.EqName}}(value {{.Type}}) streams.Predicate[{{$t.Name}}] {
+	return streams.NewPredicate[{{$t.Name}}](func(v {{$t.Name}}) bool {
+{{- if .IsTime}}
+		return v.{{.Name}}.Equal(value)
+{{- else}}
+		return v.{{.Name}} == value
+{{- end}}
+	})
+}
+
+// {{.InName}} returns a Predicate that tests whether {{$t.Name}}.{{.Name}} equals any of the specified values
+func {{.InName}}(values ...{{.Type}}) streams.Predicate[{{$t.Name}}] {
+	return streams.NewPredicate[{{$t.Name}}](func(v {{$t.Name}}) bool {
+		for _, value := range values {
+{{- if .IsTime}}
+			if v.{{.Name}}.Equal(value) {
+{{- else}}
+			if v.{{.Name}} == value {
+{{- end}}
+				return true
+			}
+		}
+		return false
+	})
+}
+{{end}}{{if .Ordered}}
+// {{.Between}} returns a Predicate that tests whether {{$t.Name}}.{{.Name}} is between the specified lo and hi (inclusive)
+func {{.Between}}(lo, hi {{.Type}}) streams.Predicate[{{$t.Name}}] {
+	return streams.NewPredicate[{{$t.Name}}](func(v {{$t.Name}}) bool {
+{{- if .IsTime}}
+		return !v.{{.Name}}.Before(lo) && !v.{{.Name}}.After(hi)
+{{- else}}
+		return v.{{.Name}} >= lo && v.{{.Name}} <= hi
+{{- end}}
+	})
+}
+{{end}}{{end}}{{end}}`))
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate_Golden(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cfg       config
+		firstFile string
+	}{
+		{
+			name:      "people",
+			cfg:       config{dir: filepath.Join("testdata", "people")},
+			firstFile: "people.go",
+		},
+		{
+			name: "prefixed",
+			cfg: config{
+				dir:        filepath.Join("testdata", "prefixed"),
+				types:      []string{"Order", " Customer"},
+				typePrefix: true,
+			},
+			firstFile: "prefixed.go",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src, firstFile, err := generate(tc.cfg)
+			require.NoError(t, err)
+			require.Equal(t, tc.firstFile, firstFile)
+			golden := filepath.Join("testdata", tc.name+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, src, 0644))
+			}
+			expect, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(expect), string(src))
+		})
+	}
+}
+
+func TestGenerate_IgnoresGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	input, err := os.ReadFile(filepath.Join("testdata", "people", "people.go"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "people.go"), input, 0644))
+	src, _, err := generate(config{dir: dir})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "people_streams.go"), src, 0644))
+
+	// generating again would clash with the identifiers in the previously generated file if it were not ignored...
+	src2, _, err := generate(config{dir: dir})
+	require.NoError(t, err)
+	require.Equal(t, string(src), string(src2))
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	testCases := []struct {
+		cfg       config
+		expectErr string
+	}{
+		{
+			cfg:       config{dir: filepath.Join("testdata", "clash")},
+			expectErr: "generated identifier IDKey (for Customer.ID) clashes with Order.ID - use -typeprefix",
+		},
+		{
+			cfg:       config{dir: filepath.Join("testdata", "people"), types: []string{"Unknown"}},
+			expectErr: "struct type Unknown not found in package people",
+		},
+		{
+			cfg:       config{dir: filepath.Join("testdata", "people"), types: []string{"Status"}},
+			expectErr: "struct type Status not found in package people",
+		},
+		{
+			cfg:       config{dir: filepath.Join("testdata", "prefixed")},
+			expectErr: "no types specified (use -type or annotate structs with //streams:generate)",
+		},
+		{
+			cfg:       config{dir: filepath.Join("testdata", "missing")},
+			expectErr: "open testdata/missing: no such file or directory",
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.expectErr, func(t *testing.T) {
+			_, _, err := generate(tc.cfg)
+			require.Error(t, err, i)
+			require.Equal(t, tc.expectErr, err.Error(), i)
+		})
+	}
+}
+
+func TestGenerate_AlreadyDeclared(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orders.go"), []byte(`package orders
+
+//streams:generate
+type Order struct {
+	ID int
+}
+
+func ByID() {}
+`), 0644))
+	_, _, err := generate(config{dir: dir})
+	require.Error(t, err)
+	require.Equal(t, "generated identifier ByID (for Order.ID) is already declared in package orders - use -typeprefix", err.Error())
+
+	src, _, err := generate(config{dir: dir, typePrefix: true})
+	require.NoError(t, err)
+	require.Contains(t, string(src), "var OrderByID = streams.NewComparator[Order](")
+}
+
+func TestGenerate_DefinedTimeTypeCompiles(t *testing.T) {
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	src, _, err := generate(config{dir: filepath.Join("testdata", "defined")})
+	require.NoError(t, err)
+	// a type defined as time.Time does not have its methods - so is not ordered (but an alias is)...
+	require.NotContains(t, string(src), "func AtBetween(")
+	require.NotContains(t, string(src), "At.Before")
+	require.Contains(t, string(src), "func OccurredBetween(")
+	require.Contains(t, string(src), "func LoggedBetween(")
+
+	// the generated code compiles (the directory must be within the module to resolve the streams import)...
+	dir, err := os.MkdirTemp("testdata", "compile-")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	input, err := os.ReadFile(filepath.Join("testdata", "defined", "defined.go"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "defined.go"), input, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "defined_streams.go"), src, 0644))
+	out, err := exec.Command(goCmd, "build", "./"+filepath.ToSlash(dir)).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestGenerate_ExcludesBuildConstrainedFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orders.go"), []byte(`package orders
+
+//streams:generate
+type Order struct {
+	ID int
+}
+`), 0644))
+	// files excluded by build constraints (tags or file name) would otherwise clash...
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orders_excluded.go"), []byte(`//go:build streamsgen_excluded
+
+package orders
+
+//streams:generate
+type Order struct {
+	Other int
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orders_plan9_mips.go"), []byte(`package other
+`), 0644))
+	src, _, err := generate(config{dir: dir})
+	require.NoError(t, err)
+	require.Contains(t, string(src), "func IDKey(v Order) int {")
+	require.NotContains(t, string(src), "Other")
+}
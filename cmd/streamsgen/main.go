@@ -0,0 +1,72 @@
+// Command streamsgen - generates type-safe comparators, predicate constructors and key extractors for structs
+/*
+Intended for use with go:generate, for example
+
+	//go:generate go run github.com/go-andiamo/streams/cmd/streamsgen -type Person
+
+	//streams:generate
+	type Person struct {
+	    Name      string
+	    Age       int
+	    CreatedAt time.Time
+	}
+
+For each exported field of each struct (either named by the -type flag, or annotated with a //streams:generate comment) it generates...
+  - a key extractor (e.g. NameKey(v Person) string)
+  - a comparator for ordered field types (e.g. ByName, ByCreatedAt)
+  - predicate constructors - Eq and In for comparable field types, Between for ordered field types (e.g. NameEq, AgeBetween)
+
+Fields tagged with `streams:"-"` are skipped
+
+Flags:
+
+	-type       comma separated list of struct type names (default: structs annotated with //streams:generate)
+	-output     output file name (default: <first file>_streams.go, where first file is $GOFILE or the first file of the package)
+	-typeprefix prefix generated identifiers with the struct type name (e.g. PersonByName rather than ByName)
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of struct type names")
+	output := flag.String("output", "", "output file name")
+	typePrefix := flag.Bool("typeprefix", false, "prefix generated identifiers with the struct type name")
+	flag.Parse()
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	cfg := config{
+		dir:        dir,
+		typePrefix: *typePrefix,
+	}
+	if *typeNames != "" {
+		cfg.types = strings.Split(*typeNames, ",")
+	}
+	src, firstFile, err := generate(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "streamsgen:", err)
+		os.Exit(1)
+	}
+	out := *output
+	if out == "" {
+		if gf := os.Getenv("GOFILE"); gf != "" {
+			firstFile = gf
+		}
+		out = strings.TrimSuffix(filepath.Base(firstFile), ".go") + "_streams.go"
+	}
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(dir, out)
+	}
+	if err = os.WriteFile(out, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "streamsgen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,11 @@
+package clash
+
+//streams:generate
+type Order struct {
+	ID int
+}
+
+//streams:generate
+type Customer struct {
+	ID int
+}
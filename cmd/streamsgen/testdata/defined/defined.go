@@ -0,0 +1,19 @@
+package defined
+
+import (
+	"time"
+)
+
+// Stamp is a defined type - so does not have the methods of time.Time
+type Stamp time.Time
+
+// Moment is an alias - so has the methods of time.Time
+type Moment = time.Time
+
+//streams:generate
+type Event struct {
+	Name     string
+	At       Stamp
+	Occurred Moment
+	Logged   time.Time
+}
@@ -0,0 +1,8 @@
+//go:build streamsgen_excluded
+
+package defined
+
+// Event is declared again - but this file is excluded by its build constraint
+type Event struct {
+	Other int
+}
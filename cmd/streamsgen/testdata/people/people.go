@@ -0,0 +1,33 @@
+package people
+
+import (
+	"net/url"
+	"time"
+)
+
+type Status string
+
+//streams:generate
+type Person struct {
+	Name      string
+	Age       int
+	Height    float64
+	Active    bool
+	Status    Status
+	CreatedAt time.Time
+	Homepage  *url.URL
+	Tags      []string
+	Secret    string `streams:"-"`
+	internal  int
+}
+
+type NotGenerated struct {
+	Name string
+}
+
+// Team is annotated in the doc comment
+//
+//streams:generate
+type Team struct {
+	Title string
+}
@@ -0,0 +1,13 @@
+package prefixed
+
+import t "time"
+
+type Order struct {
+	ID     int
+	Placed t.Time
+}
+
+type Customer struct {
+	ID   int
+	Name string
+}
@@ -0,0 +1,163 @@
+package streams
+
+// Pair is a generic pair of values
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Product creates a new stream of the cartesian product of the two supplied streams
+//
+// the resulting stream is lazy - pairs are only produced as they are consumed (so, for example, using Limit on the
+// result does not produce the entire product)
+func Product[A any, B any](a Stream[A], b Stream[B]) Stream[Pair[A, B]] {
+	nextA := a.Iterator()
+	var nextB func() (B, bool)
+	var currA A
+	return newLazyStream[Pair[A, B]](func() (Pair[A, B], bool) {
+		for {
+			if nextB != nil {
+				if vb, ok := nextB(); ok {
+					return Pair[A, B]{First: currA, Second: vb}, true
+				}
+			}
+			va, ok := nextA()
+			if !ok {
+				return Pair[A, B]{}, false
+			}
+			currA, nextB = va, b.Iterator()
+		}
+	})
+}
+
+// ProductN creates a new stream of the n-ary cartesian product of the supplied streams
+//
+// each element of the resulting stream is a slice containing one element from each of the supplied streams (in the order
+// that the streams were supplied) - with the last stream varying fastest
+//
+// if no streams are supplied, or any of the supplied streams is empty, the result is empty
+//
+// the resulting stream is lazy - products are only produced as they are consumed
+func ProductN[T any](streams ...Stream[T]) Stream[[]T] {
+	if len(streams) == 0 {
+		return &stream[[]T]{}
+	}
+	values := make([][]T, len(streams))
+	for i, s := range streams {
+		if values[i] = s.AsSlice(); len(values[i]) == 0 {
+			return &stream[[]T]{}
+		}
+	}
+	var indices []int
+	return newLazyStream[[]T](func() ([]T, bool) {
+		if indices == nil {
+			indices = make([]int, len(values))
+		} else {
+			// advance the odometer...
+			i := len(indices) - 1
+			for ; i >= 0; i-- {
+				if indices[i]++; indices[i] < len(values[i]) {
+					break
+				}
+				indices[i] = 0
+			}
+			if i < 0 {
+				return nil, false
+			}
+		}
+		r := make([]T, len(indices))
+		for i, vi := range indices {
+			r[i] = values[i][vi]
+		}
+		return r, true
+	})
+}
+
+// Combinations creates a new stream of all the k length combinations of elements in the supplied stream
+//
+// combinations are produced in lexicographic order of element positions - and elements are treated as unique
+// based on their position, not their value (so the supplied stream containing equal elements will produce repeated combinations)
+//
+// if k is negative or greater than the number of elements in the supplied stream, the result is empty
+//
+// the resulting stream is lazy - combinations are only produced as they are consumed
+func Combinations[T any](s Stream[T], k int) Stream[[]T] {
+	values := s.AsSlice()
+	n := len(values)
+	if k < 0 || k > n {
+		return &stream[[]T]{}
+	}
+	var indices []int
+	return newLazyStream[[]T](func() ([]T, bool) {
+		if indices == nil {
+			indices = make([]int, k)
+			for i := range indices {
+				indices[i] = i
+			}
+		} else {
+			i := k - 1
+			for ; i >= 0 && indices[i] == i+n-k; i-- {
+			}
+			if i < 0 {
+				return nil, false
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+		return pickIndices(values, indices), true
+	})
+}
+
+// Permutations creates a new stream of all the k length permutations of elements in the supplied stream
+//
+// permutations are produced in lexicographic order of element positions - and elements are treated as unique
+// based on their position, not their value (so the supplied stream containing equal elements will produce repeated permutations)
+//
+// if k is negative or greater than the number of elements in the supplied stream, the result is empty
+//
+// the resulting stream is lazy - permutations are only produced as they are consumed
+func Permutations[T any](s Stream[T], k int) Stream[[]T] {
+	values := s.AsSlice()
+	n := len(values)
+	if k < 0 || k > n {
+		return &stream[[]T]{}
+	}
+	var indices, cycles []int
+	return newLazyStream[[]T](func() ([]T, bool) {
+		if indices == nil {
+			indices = make([]int, n)
+			for i := range indices {
+				indices[i] = i
+			}
+			cycles = make([]int, k)
+			for i := range cycles {
+				cycles[i] = n - i
+			}
+			return pickIndices(values, indices[:k]), true
+		}
+		for i := k - 1; i >= 0; i-- {
+			if cycles[i]--; cycles[i] == 0 {
+				// rotate the index at i to the end...
+				first := indices[i]
+				copy(indices[i:], indices[i+1:])
+				indices[n-1] = first
+				cycles[i] = n - i
+			} else {
+				j := n - cycles[i]
+				indices[i], indices[j] = indices[j], indices[i]
+				return pickIndices(values, indices[:k]), true
+			}
+		}
+		return nil, false
+	})
+}
+
+func pickIndices[T any](values []T, indices []int) []T {
+	r := make([]T, len(indices))
+	for i, vi := range indices {
+		r[i] = values[vi]
+	}
+	return r
+}
@@ -0,0 +1,84 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProduct(t *testing.T) {
+	s := Product(Of("a", "b"), Of(1, 2, 3))
+	require.Equal(t, []Pair[string, int]{
+		{"a", 1}, {"a", 2}, {"a", 3},
+		{"b", 1}, {"b", 2}, {"b", 3},
+	}, s.AsSlice())
+
+	require.Equal(t, 0, Product(Of[string](), Of(1, 2, 3)).Len())
+	require.Equal(t, 0, Product(Of("a", "b"), Of[int]()).Len())
+}
+
+func TestProduct_Lazy(t *testing.T) {
+	a, pulledA := testLazyStream(1, 2, 3, 4, 5)
+	b, pulledB := testLazyStream("a", "b", "c", "d", "e")
+	s := Product[int, string](a, b)
+	require.Equal(t, 0, *pulledA)
+	require.Equal(t, []Pair[int, string]{{1, "a"}, {1, "b"}}, s.Limit(2).AsSlice())
+	require.Equal(t, 1, *pulledA)
+	require.Equal(t, 2, *pulledB)
+	require.Equal(t, 25, s.Len())
+}
+
+func TestProductN(t *testing.T) {
+	s := ProductN(Of(1, 2), Of(3), Of(4, 5))
+	require.Equal(t, [][]int{
+		{1, 3, 4}, {1, 3, 5},
+		{2, 3, 4}, {2, 3, 5},
+	}, s.AsSlice())
+
+	require.Equal(t, [][]int{{1}, {2}}, ProductN(Of(1, 2)).AsSlice())
+	require.Equal(t, 0, ProductN[int]().Len())
+	require.Equal(t, 0, ProductN(Of(1, 2), Of[int]()).Len())
+}
+
+func TestCombinations(t *testing.T) {
+	s := Combinations(Of("a", "b", "c", "d"), 2)
+	require.Equal(t, [][]string{
+		{"a", "b"}, {"a", "c"}, {"a", "d"},
+		{"b", "c"}, {"b", "d"},
+		{"c", "d"},
+	}, s.AsSlice())
+
+	require.Equal(t, [][]string{{"a", "b", "c"}}, Combinations(Of("a", "b", "c"), 3).AsSlice())
+	require.Equal(t, [][]string{{}}, Combinations(Of("a", "b", "c"), 0).AsSlice())
+	require.Equal(t, 0, Combinations(Of("a", "b", "c"), 4).Len())
+	require.Equal(t, 0, Combinations(Of("a", "b", "c"), -1).Len())
+	require.Equal(t, 1, Combinations(Of("a", "a"), 2).Len())
+
+	values := make([]int, 30)
+	s2 := Combinations(Of(values...), 15)
+	require.Equal(t, 10, s2.Limit(10).Len())
+}
+
+func TestPermutations(t *testing.T) {
+	s := Permutations(Of("a", "b", "c"), 2)
+	require.Equal(t, [][]string{
+		{"a", "b"}, {"a", "c"},
+		{"b", "a"}, {"b", "c"},
+		{"c", "a"}, {"c", "b"},
+	}, s.AsSlice())
+
+	s = Permutations(Of("a", "b", "c"), 3)
+	require.Equal(t, [][]string{
+		{"a", "b", "c"}, {"a", "c", "b"},
+		{"b", "a", "c"}, {"b", "c", "a"},
+		{"c", "a", "b"}, {"c", "b", "a"},
+	}, s.AsSlice())
+
+	require.Equal(t, 24, Permutations(Of(1, 2, 3, 4), 4).Len())
+	require.Equal(t, 12, Permutations(Of(1, 2, 3, 4), 2).Len())
+	require.Equal(t, [][]int{{}}, Permutations(Of(1, 2), 0).AsSlice())
+	require.Equal(t, 0, Permutations(Of(1, 2), 3).Len())
+	require.Equal(t, 0, Permutations(Of(1, 2), -1).Len())
+
+	values := make([]int, 20)
+	require.Equal(t, 5, Permutations(Of(values...), 20).Limit(5).Len())
+}
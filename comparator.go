@@ -1,5 +1,7 @@
 package streams
 
+import "reflect"
+
 // Comparator is the interface used to compare elements of a Stream
 //
 // This interface is used when sorting, when finding min/max of a stream
@@ -40,7 +42,7 @@ func NewComparator[T any](f ComparatorFunc[T]) Comparator[T] {
 	if f == nil {
 		return nil
 	}
-	return comparator[T]{
+	return &comparator[T]{
 		f: f,
 	}
 }
@@ -117,7 +119,7 @@ func (c comparator[T]) NotEqual(v1, v2 T) bool {
 //
 // the reversal is against less/greater as well as against equality/non-equality
 func (c comparator[T]) Reversed() Comparator[T] {
-	return comparator[T]{
+	return &comparator[T]{
 		inner:    c,
 		reversed: !c.reversed,
 	}
@@ -126,7 +128,7 @@ func (c comparator[T]) Reversed() Comparator[T] {
 // Then creates a new comparator from this comparator, with a following then comparator
 // that is used when the initial comparison yields equal
 func (c comparator[T]) Then(other Comparator[T]) Comparator[T] {
-	return comparator[T]{
+	return &comparator[T]{
 		inner: c,
 		then:  other,
 	}
@@ -178,3 +180,16 @@ func (f ComparatorFunc[T]) Reversed() Comparator[T] {
 func (f ComparatorFunc[T]) Then(other Comparator[T]) Comparator[T] {
 	return NewComparator[T](f).Then(other)
 }
+
+// sameComparator determines whether the two comparators are the same comparator (i.e. the same instance)
+//
+// comparators whose underlying type is not comparable (e.g. a ComparatorFunc) are never deemed to be the same
+func sameComparator[T any](c1, c2 Comparator[T]) bool {
+	if c1 == nil || c2 == nil {
+		return false
+	}
+	if t := reflect.TypeOf(c1); t != reflect.TypeOf(c2) || !t.Comparable() {
+		return false
+	}
+	return c1 == c2
+}
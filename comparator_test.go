@@ -427,3 +427,15 @@ func TestComparatorFunc_Then(t *testing.T) {
 		})
 	}
 }
+
+func TestSameComparator(t *testing.T) {
+	require.True(t, sameComparator(StringComparator, StringComparator))
+	require.False(t, sameComparator(StringComparator, StringInsensitiveComparator))
+	require.False(t, sameComparator(StringComparator, nil))
+	require.False(t, sameComparator[string](nil, nil))
+	f := ComparatorFunc[string](StringComparator.Compare)
+	require.False(t, sameComparator[string](f, f))
+	r := StringComparator.Reversed()
+	require.True(t, sameComparator(r, r))
+	require.False(t, sameComparator(r, StringComparator.Reversed()))
+}
@@ -0,0 +1,173 @@
+package streams
+
+import (
+	"reflect"
+)
+
+// DistinctOption is an option for Distinct, DistinctBy and UniqueBy
+type DistinctOption uint
+
+const (
+	// KeepLast is the option to keep the last occurrence of each duplicate (rather than the first) - the resulting
+	// elements are in the order of those last occurrences
+	KeepLast DistinctOption = 1 << iota
+)
+
+// Distinct creates a new stream of the distinct elements of the supplied stream
+//
+// unlike Stream.Distinct, the element type must be comparable - so it is checked at compile time that the elements can be hashed
+func Distinct[T comparable](s Stream[T], options ...DistinctOption) Stream[T] {
+	return DistinctBy[T, T](s, func(v T) T {
+		return v
+	}, options...)
+}
+
+// DistinctBy creates a new stream of the elements of the supplied stream that are distinct according to the key returned
+// by the provided key extractor
+//
+// by default, the first element with each key is kept (see KeepLast option)
+//
+// if the provided key extractor is nil, the result is always empty
+func DistinctBy[T any, K comparable](s Stream[T], key func(v T) K, options ...DistinctOption) Stream[T] {
+	r := &stream[T]{}
+	if key != nil {
+		seen := map[K]bool{}
+		r.elements = distinctFilter[T](elementsOf(s), distinctOptions(options).has(KeepLast), func(v T) bool {
+			k := key(v)
+			if seen[k] {
+				return false
+			}
+			seen[k] = true
+			return true
+		})
+	}
+	return r
+}
+
+// UniqueBy creates a new stream of the elements of the supplied stream that are unique according to the key returned
+// by the provided key extractor - where uniqueness of keys is determined using the provided comparator
+//
+// by default, the first element with each key is kept (see KeepLast option)
+//
+// if the provided key extractor or comparator is nil, the result is always empty
+func UniqueBy[T any, K any](s Stream[T], key func(v T) K, c Comparator[K], options ...DistinctOption) Stream[T] {
+	r := &stream[T]{}
+	if key != nil && c != nil {
+		es := elementsOf(s)
+		keys := make([]K, len(es))
+		for i, v := range es {
+			keys[i] = key(v)
+		}
+		bag := newBagCounter[K](keys, c)
+		seen := map[int]bool{}
+		r.elements = distinctFilter[T](es, distinctOptions(options).has(KeepLast), func(v T) bool {
+			k := bag.key(key(v))
+			if seen[k] {
+				return false
+			}
+			seen[k] = true
+			return true
+		})
+	}
+	return r
+}
+
+type distinctOptions []DistinctOption
+
+func (os distinctOptions) has(option DistinctOption) bool {
+	for _, o := range os {
+		if o&option != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// distinctFilter returns the elements for which first returns true - visiting the elements in reverse when keeping last
+func distinctFilter[T any](elements []T, keepLast bool, first func(v T) bool) []T {
+	r := make([]T, 0)
+	if keepLast {
+		for i := len(elements) - 1; i >= 0; i-- {
+			if first(elements[i]) {
+				r = append(r, elements[i])
+			}
+		}
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+	} else {
+		for _, v := range elements {
+			if first(v) {
+				r = append(r, v)
+			}
+		}
+	}
+	return r
+}
+
+// distinctElements returns the distinct elements - where elements are hashed if possible, otherwise (e.g. slices, maps or funcs)
+// compared using reflect.DeepEqual
+func distinctElements[T any](elements []T) []T {
+	hashed := map[any]bool{}
+	unhashable := make([]T, 0)
+	return distinctFilter[T](elements, false, func(v T) bool {
+		if added, ok := addHashed(hashed, v); ok {
+			return added
+		}
+		for _, u := range unhashable {
+			if reflect.DeepEqual(u, v) {
+				return false
+			}
+		}
+		unhashable = append(unhashable, v)
+		return true
+	})
+}
+
+// addHashed adds the value to the hashed set - returning whether it was added, and false for ok if the value is not hashable
+func addHashed(hashed map[any]bool, v any) (added bool, ok bool) {
+	if !isHashable(v) {
+		return false, false
+	}
+	if hashed[v] {
+		return false, true
+	}
+	hashed[v] = true
+	return true, true
+}
+
+// isHashable returns whether the value can be used as a map key (i.e. hashing it will not panic)
+//
+// a comparable type is not sufficient on its own - as interface typed fields (or array elements) may hold unhashable values
+func isHashable(v any) bool {
+	if v == nil {
+		return true
+	}
+	if !reflect.TypeOf(v).Comparable() {
+		return false
+	}
+	return isHashableValue(reflect.ValueOf(v))
+}
+
+func isHashableValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Interface:
+		return rv.IsNil() || (rv.Elem().Type().Comparable() && isHashableValue(rv.Elem()))
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if !isHashableValue(rv.Field(i)) {
+				return false
+			}
+		}
+	case reflect.Array:
+		if k := rv.Type().Elem().Kind(); k != reflect.Interface && k != reflect.Struct && k != reflect.Array {
+			return true
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if !isHashableValue(rv.Index(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDistinct(t *testing.T) {
+	s := Of("a", "b", "a", "c", "b")
+	require.Equal(t, []string{"a", "b", "c"}, Distinct(s).AsSlice())
+	require.Equal(t, []string{"a", "c", "b"}, Distinct(s, KeepLast).AsSlice())
+	require.Equal(t, 0, Distinct[string](nil).Len())
+}
+
+func TestDistinctBy(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	s := Of(person{"Bilbo", 111}, person{"Frodo", 33}, person{"Sam", 38}, person{"Merry", 33}, person{"Gandalf", 111})
+	r := DistinctBy(s, func(v person) int {
+		return v.age
+	})
+	require.Equal(t, []person{{"Bilbo", 111}, {"Frodo", 33}, {"Sam", 38}}, r.AsSlice())
+	r = DistinctBy(s, func(v person) int {
+		return v.age
+	}, KeepLast)
+	require.Equal(t, []person{{"Sam", 38}, {"Merry", 33}, {"Gandalf", 111}}, r.AsSlice())
+	require.Equal(t, 0, DistinctBy[person, int](s, nil).Len())
+
+	// non-comparable elements with comparable key...
+	s2 := Of([]int{1, 2}, []int{3}, []int{1, 2})
+	r2 := DistinctBy(s2, func(v []int) int {
+		return len(v)
+	})
+	require.Equal(t, [][]int{{1, 2}, {3}}, r2.AsSlice())
+}
+
+func TestUniqueBy(t *testing.T) {
+	s := Of("Apple", "banana", "avocado", "Blueberry", "cherry")
+	first := func(v string) string {
+		return v[:1]
+	}
+	r := UniqueBy(s, first, StringInsensitiveComparator)
+	require.Equal(t, []string{"Apple", "banana", "cherry"}, r.AsSlice())
+	r = UniqueBy(s, first, StringInsensitiveComparator, KeepLast)
+	require.Equal(t, []string{"avocado", "Blueberry", "cherry"}, r.AsSlice())
+	r = UniqueBy(s, first, StringComparator)
+	require.Equal(t, []string{"Apple", "banana", "avocado", "Blueberry", "cherry"}, r.AsSlice())
+	require.Equal(t, 0, UniqueBy(s, first, nil).Len())
+	require.Equal(t, 0, UniqueBy[string, string](s, nil, StringComparator).Len())
+}
+
+func TestStream_Distinct_Unhashable(t *testing.T) {
+	s := Of([]int{1, 2}, []int{3}, []int{1, 2}, nil, nil)
+	require.NotPanics(t, func() {
+		r := s.Distinct()
+		require.Equal(t, [][]int{{1, 2}, {3}, nil}, r.AsSlice())
+	})
+	require.Equal(t, 3, s.Unique(nil).Len())
+	s2 := Of[any]("a", []string{"b"}, "a", map[string]int{"c": 1}, []string{"b"}, map[string]int{"c": 1})
+	require.Equal(t, []any{"a", []string{"b"}, map[string]int{"c": 1}}, s2.Distinct().AsSlice())
+	s3 := Streamable[map[string]int]{{"a": 1}, {"a": 1}}
+	require.Equal(t, 1, s3.Distinct().Len())
+	sl := []func(){nil, nil}
+	require.Equal(t, 1, NewStreamableSlice(&sl).Distinct().Len())
+	require.Equal(t, []string{"a"}, Of("a", "a").Unique(nil).AsSlice())
+}
+
+func TestStream_Distinct_UnhashableInComparable(t *testing.T) {
+	type holder struct {
+		v any
+	}
+	s := Of(holder{[]int{1}}, holder{"a"}, holder{[]int{1}}, holder{"a"}, holder{[1]any{map[string]int{}}})
+	require.Equal(t, []holder{{[]int{1}}, {"a"}, {[1]any{map[string]int{}}}}, s.Distinct().AsSlice())
+}
+
+func TestIsHashable(t *testing.T) {
+	type holder struct {
+		v any
+	}
+	require.True(t, isHashable(nil))
+	require.True(t, isHashable(1))
+	require.True(t, isHashable("a"))
+	require.True(t, isHashable(holder{}))
+	require.True(t, isHashable(holder{"a"}))
+	require.True(t, isHashable([2]any{1, "a"}))
+	require.False(t, isHashable([]int{}))
+	require.False(t, isHashable(map[string]int{}))
+	require.False(t, isHashable(func() {}))
+	require.False(t, isHashable(holder{[]int{}}))
+	require.False(t, isHashable(holder{holder{map[string]int{}}}))
+	require.False(t, isHashable([2]any{1, []int{}}))
+}
@@ -0,0 +1,325 @@
+package streams
+
+import (
+	"fmt"
+)
+
+// Equal returns whether the two streams have equal elements in the same order
+//
+// equality of elements is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false (a nil stream is treated as empty)
+func Equal[T any](a, b Stream[T], c Comparator[T]) bool {
+	if c == nil {
+		return false
+	}
+	as, bs := elementsOf(a), elementsOf(b)
+	if len(as) != len(bs) {
+		return false
+	}
+	for i, v := range as {
+		if c.Compare(v, bs[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualIgnoringOrder returns whether the two streams have equal elements, regardless of their order
+//
+// the number of occurrences of each element is significant - i.e. the streams are compared as multisets
+//
+// equality (and ordering) of elements is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false (a nil stream is treated as empty)
+func EqualIgnoringOrder[T any](a, b Stream[T], c Comparator[T]) bool {
+	if c == nil {
+		return false
+	}
+	as, bs := elementsOf(a), elementsOf(b)
+	if len(as) != len(bs) {
+		return false
+	}
+	return Equal[T](newSortedStream[T](as, c), newSortedStream[T](bs, c), c)
+}
+
+// ContainsAll returns whether stream a contains all the elements of stream b
+//
+// the number of occurrences of each element is not significant
+//
+// equality (and ordering) of elements is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false (a nil stream is treated as empty)
+func ContainsAll[T any](a, b Stream[T], c Comparator[T]) bool {
+	if c == nil {
+		return false
+	}
+	sa := newSortedStream[T](elementsOf(a), c)
+	for _, v := range elementsOf(b) {
+		if _, found := sa.BinarySearch(v); !found {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubsetOf returns whether all the elements of stream a are contained in stream b
+//
+// the number of occurrences of each element is not significant
+//
+// equality (and ordering) of elements is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false (a nil stream is treated as empty)
+func IsSubsetOf[T any](a, b Stream[T], c Comparator[T]) bool {
+	return ContainsAll[T](b, a, c)
+}
+
+// Hash returns a hash (fingerprint) of the elements of the stream - where each element is hashed using the provided
+// hash function
+//
+// the hash is order sensitive - so streams that are Equal have equal hashes (provided the hash function is consistent
+// with the comparator used for equality)
+//
+// if the provided hash function is nil, always returns 0 (a nil stream is treated as empty)
+func Hash[T any](s Stream[T], h func(v T) uint64) uint64 {
+	if h == nil {
+		return 0
+	}
+	r := uint64(fnvOffset64)
+	for _, v := range elementsOf(s) {
+		r = (r ^ h(v)) * fnvPrime64
+	}
+	return r
+}
+
+// HashIgnoringOrder returns a hash (fingerprint) of the elements of the stream, regardless of their order - where each
+// element is hashed using the provided hash function
+//
+// the number of occurrences of each element is significant - so streams that are EqualIgnoringOrder have equal hashes
+// (provided the hash function is consistent with the comparator used for equality)
+//
+// if the provided hash function is nil, always returns 0 (a nil stream is treated as empty)
+func HashIgnoringOrder[T any](s Stream[T], h func(v T) uint64) uint64 {
+	if h == nil {
+		return 0
+	}
+	r := uint64(fnvOffset64)
+	for _, v := range elementsOf(s) {
+		r += mixHash(h(v))
+	}
+	return r
+}
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// mixHash scrambles the bits of an element hash (splitmix64 finalizer) - so that summing element hashes does not
+// cancel out
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	return h ^ (h >> 31)
+}
+
+// EditOp is the operation of an Edit
+type EditOp int
+
+const (
+	EditKeep   EditOp = iota // EditKeep the element is in both streams
+	EditDelete               // EditDelete the element is only in the first (old) stream
+	EditInsert               // EditInsert the element is only in the second (new) stream
+)
+
+// String returns the name of the edit operation
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	}
+	return fmt.Sprintf("EditOp(%d)", int(op))
+}
+
+// Edit is an element of an edit script produced by Diff
+type Edit[T any] struct {
+	Op       EditOp // the edit operation
+	Value    T      // the element value
+	OldIndex int    // the index of the element in the old stream (-1 for EditInsert)
+	NewIndex int    // the index of the element in the new stream (-1 for EditDelete)
+}
+
+// String renders the edit in unified diff style - i.e. "  " (keep), "- " (delete) or "+ " (insert) followed by the value
+func (e Edit[T]) String() string {
+	prefix := "  "
+	switch e.Op {
+	case EditDelete:
+		prefix = "- "
+	case EditInsert:
+		prefix = "+ "
+	}
+	return fmt.Sprintf("%s%v", prefix, e.Value)
+}
+
+// Diff creates a stream of edits (an edit script) that transforms stream a (old) into stream b (new)
+//
+// the edit script is a shortest edit script (using the Myers difference algorithm) - with deletes placed before inserts
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func Diff[T any](a, b Stream[T], c Comparator[T]) Stream[Edit[T]] {
+	r := &stream[Edit[T]]{}
+	if c != nil {
+		r.elements = myersDiff[T](elementsOf(a), elementsOf(b), c)
+	}
+	return r
+}
+
+// myersDiff produces a shortest edit script using the linear space variant of the Myers algorithm - i.e. recursively
+// finding the middle snake (rather than keeping the trace of every furthest reaching path)
+func myersDiff[T any](as, bs []T, c Comparator[T]) []Edit[T] {
+	sz := len(as) + len(bs) + 3
+	md := &myersDiffer[T]{
+		as:    as,
+		bs:    bs,
+		c:     c,
+		vf:    make([]int, sz),
+		vb:    make([]int, sz),
+		edits: make([]Edit[T], 0, len(as)+len(bs)),
+	}
+	md.diff(0, len(as), 0, len(bs))
+	return md.deletesFirst()
+}
+
+type myersDiffer[T any] struct {
+	as    []T
+	bs    []T
+	c     Comparator[T]
+	vf    []int // furthest reaching x per diagonal (forward)
+	vb    []int // furthest reaching x per diagonal (backward - i.e. from the ends of the sequences)
+	edits []Edit[T]
+}
+
+func (md *myersDiffer[T]) equal(x, y int) bool {
+	return md.c.Compare(md.as[x], md.bs[y]) == 0
+}
+
+func (md *myersDiffer[T]) keep(x, y int, n int) {
+	for i := 0; i < n; i++ {
+		md.edits = append(md.edits, Edit[T]{Op: EditKeep, Value: md.as[x+i], OldIndex: x + i, NewIndex: y + i})
+	}
+}
+
+// diff appends the edits that transform as[a0:a1] into bs[b0:b1]
+func (md *myersDiffer[T]) diff(a0, a1, b0, b1 int) {
+	// common prefix and suffix are always kept...
+	pre := 0
+	for a0+pre < a1 && b0+pre < b1 && md.equal(a0+pre, b0+pre) {
+		pre++
+	}
+	md.keep(a0, b0, pre)
+	a0, b0 = a0+pre, b0+pre
+	suf := 0
+	for a1-suf > a0 && b1-suf > b0 && md.equal(a1-suf-1, b1-suf-1) {
+		suf++
+	}
+	a1, b1 = a1-suf, b1-suf
+	if a0 == a1 {
+		for y := b0; y < b1; y++ {
+			md.edits = append(md.edits, Edit[T]{Op: EditInsert, Value: md.bs[y], OldIndex: -1, NewIndex: y})
+		}
+	} else if b0 == b1 {
+		for x := a0; x < a1; x++ {
+			md.edits = append(md.edits, Edit[T]{Op: EditDelete, Value: md.as[x], OldIndex: x, NewIndex: -1})
+		}
+	} else {
+		x, y, u, v := md.middleSnake(a0, a1, b0, b1)
+		md.diff(a0, a0+x, b0, b0+y)
+		md.keep(a0+x, b0+y, u-x)
+		md.diff(a0+u, a1, b0+v, b1)
+	}
+	md.keep(a1, b1, suf)
+}
+
+// middleSnake finds the middle snake of a shortest edit script of as[a0:a1] into bs[b0:b1] - returning the start (x, y)
+// and end (u, v) of the snake (relative to a0 and b0)
+func (md *myersDiffer[T]) middleSnake(a0, a1, b0, b1 int) (x, y, u, v int) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta&1 != 0
+	max := (n + m + 1) / 2
+	offset := max + 1
+	vf, vb := md.vf, md.vb
+	vf[offset+1], vb[offset+1] = 0, 0
+	for d := 0; d <= max; d++ {
+		// forward...
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && md.equal(a0+u, b0+v) {
+				u++
+				v++
+			}
+			vf[offset+k] = u
+			if rk := delta - k; odd && rk >= -(d-1) && rk <= d-1 && u+vb[offset+rk] >= n {
+				return x, y, u, v
+			}
+		}
+		// backward...
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && md.equal(a1-u-1, b1-v-1) {
+				u++
+				v++
+			}
+			vb[offset+k] = u
+			if fk := delta - k; !odd && fk >= -d && fk <= d && u+vf[offset+fk] >= n {
+				return n - u, m - v, n - x, m - y
+			}
+		}
+	}
+	// not reached - a middle snake always exists
+	return 0, 0, 0, 0
+}
+
+// deletesFirst re-orders each run of changes so that deletes are placed before inserts
+func (md *myersDiffer[T]) deletesFirst() []Edit[T] {
+	r := make([]Edit[T], 0, len(md.edits))
+	inserts := make([]Edit[T], 0)
+	for _, e := range md.edits {
+		switch e.Op {
+		case EditDelete:
+			r = append(r, e)
+		case EditInsert:
+			inserts = append(inserts, e)
+		default:
+			r = append(append(r, inserts...), e)
+			inserts = inserts[:0]
+		}
+	}
+	return append(r, inserts...)
+}
+
+// elementsOf returns the elements of a stream (nil stream treated as empty)
+func elementsOf[T any](s Stream[T]) []T {
+	if s == nil {
+		return nil
+	}
+	return s.AsSlice()
+}
@@ -0,0 +1,185 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	require.True(t, Equal(Of("a", "b"), Of("a", "b"), StringComparator))
+	require.True(t, Equal(Of("a", "b"), Of("A", "B"), StringInsensitiveComparator))
+	require.False(t, Equal(Of("a", "b"), Of("b", "a"), StringComparator))
+	require.False(t, Equal(Of("a", "b"), Of("a"), StringComparator))
+	require.False(t, Equal(Of("a", "b"), Of("a", "b"), nil))
+	require.True(t, Equal(nil, Of[string](), StringComparator))
+	require.True(t, Equal[string](nil, nil, StringComparator))
+}
+
+func TestEqualIgnoringOrder(t *testing.T) {
+	require.True(t, EqualIgnoringOrder(Of("a", "b", "a"), Of("a", "a", "b"), StringComparator))
+	require.False(t, EqualIgnoringOrder(Of("a", "b", "b"), Of("a", "a", "b"), StringComparator))
+	require.False(t, EqualIgnoringOrder(Of("a", "b"), Of("a", "b", "b"), StringComparator))
+	require.True(t, EqualIgnoringOrder(Of("a", "B"), Of("b", "A"), StringInsensitiveComparator))
+	require.False(t, EqualIgnoringOrder(Of("a"), Of("a"), nil))
+}
+
+func TestContainsAll(t *testing.T) {
+	require.True(t, ContainsAll(Of("a", "b", "c"), Of("c", "a", "a"), StringComparator))
+	require.False(t, ContainsAll(Of("a", "b", "c"), Of("c", "d"), StringComparator))
+	require.True(t, ContainsAll(Of("a"), nil, StringComparator))
+	require.False(t, ContainsAll(Of("a"), Of("a"), nil))
+}
+
+func TestIsSubsetOf(t *testing.T) {
+	require.True(t, IsSubsetOf(Of("c", "a"), Of("a", "b", "c"), StringComparator))
+	require.False(t, IsSubsetOf(Of("a", "b", "c"), Of("c", "a"), StringComparator))
+	require.True(t, IsSubsetOf(nil, Of("a"), StringComparator))
+	require.False(t, IsSubsetOf(Of("a"), Of("a"), nil))
+}
+
+func TestHash(t *testing.T) {
+	h := func(v string) uint64 {
+		r := uint64(0)
+		for _, ch := range v {
+			r = r*31 + uint64(ch)
+		}
+		return r
+	}
+	require.Equal(t, Hash(Of("a", "b", "c"), h), Hash(Of("a", "b", "c"), h))
+	require.NotEqual(t, Hash(Of("a", "b", "c"), h), Hash(Of("c", "b", "a"), h))
+	require.NotEqual(t, Hash(Of("a", "b"), h), Hash(Of("a", "b", "b"), h))
+	require.Equal(t, Hash[string](nil, h), Hash(Of[string](), h))
+	require.Equal(t, uint64(0), Hash(Of("a"), nil))
+}
+
+func TestHashIgnoringOrder(t *testing.T) {
+	h := func(v int) uint64 {
+		return uint64(v)
+	}
+	require.Equal(t, HashIgnoringOrder(Of(1, 2, 3), h), HashIgnoringOrder(Of(3, 1, 2), h))
+	require.NotEqual(t, HashIgnoringOrder(Of(1, 2, 3), h), HashIgnoringOrder(Of(1, 2, 4), h))
+	require.NotEqual(t, HashIgnoringOrder(Of(1, 1, 2), h), HashIgnoringOrder(Of(1, 2, 2), h))
+	require.NotEqual(t, HashIgnoringOrder(Of(1, 1), h), HashIgnoringOrder(Of[int](), h))
+	require.Equal(t, HashIgnoringOrder[int](nil, h), HashIgnoringOrder(Of[int](), h))
+	require.Equal(t, uint64(0), HashIgnoringOrder(Of(1), nil))
+}
+
+func TestEditOp_String(t *testing.T) {
+	require.Equal(t, "keep", EditKeep.String())
+	require.Equal(t, "delete", EditDelete.String())
+	require.Equal(t, "insert", EditInsert.String())
+	require.Equal(t, "EditOp(99)", EditOp(99).String())
+}
+
+func TestEdit_String(t *testing.T) {
+	require.Equal(t, "  a", Edit[string]{Op: EditKeep, Value: "a"}.String())
+	require.Equal(t, "- a", Edit[string]{Op: EditDelete, Value: "a"}.String())
+	require.Equal(t, "+ a", Edit[string]{Op: EditInsert, Value: "a"}.String())
+}
+
+func TestDiff(t *testing.T) {
+	a := strings.Split("ABCABBA", "")
+	b := strings.Split("CBABAC", "")
+	d := Diff(Of(a...), Of(b...), StringComparator)
+	lines := make([]string, 0)
+	_ = d.ForEach(NewConsumer(func(e Edit[string]) error {
+		lines = append(lines, e.String())
+		return nil
+	}))
+	require.Equal(t, []string{"- A", "+ C", "  B", "- C", "  A", "  B", "- B", "  A", "+ C"}, lines)
+	checkDiff(t, a, b, d.AsSlice())
+	require.Equal(t, 5, d.Count(NewPredicate(func(e Edit[string]) bool {
+		return e.Op != EditKeep
+	})))
+
+	d = Diff(Of("a", "b"), Of("a", "b"), StringComparator)
+	require.Equal(t, 2, d.Len())
+	require.True(t, d.AllMatch(NewPredicate(func(e Edit[string]) bool {
+		return e.Op == EditKeep
+	})))
+	d = Diff(nil, Of("a", "b"), StringComparator)
+	require.Equal(t, []Edit[string]{{Op: EditInsert, Value: "a", OldIndex: -1, NewIndex: 0}, {Op: EditInsert, Value: "b", OldIndex: -1, NewIndex: 1}}, d.AsSlice())
+	d = Diff(Of("a", "b"), nil, StringComparator)
+	require.Equal(t, []Edit[string]{{Op: EditDelete, Value: "a", OldIndex: 0, NewIndex: -1}, {Op: EditDelete, Value: "b", OldIndex: 1, NewIndex: -1}}, d.AsSlice())
+	require.Equal(t, 0, Diff[string](nil, nil, StringComparator).Len())
+	require.Equal(t, 0, Diff(Of("a"), Of("b"), nil).Len())
+}
+
+func TestDiff_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		a := make([]int, rnd.Intn(20))
+		for j := range a {
+			a[j] = rnd.Intn(5)
+		}
+		b := make([]int, rnd.Intn(20))
+		for j := range b {
+			b[j] = rnd.Intn(5)
+		}
+		checkDiff(t, a, b, Diff(Of(a...), Of(b...), IntComparator).AsSlice())
+	}
+}
+
+func TestDiff_LargeMostlyDifferent(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := make([]int, 1500)
+	for i := range a {
+		a[i] = rnd.Intn(100000)
+	}
+	b := make([]int, 1800)
+	for i := range b {
+		if i%100 == 0 && i < len(a) {
+			b[i] = a[i]
+		} else {
+			b[i] = rnd.Intn(100000)
+		}
+	}
+	checkDiff(t, a, b, Diff(Of(a...), Of(b...), IntComparator).AsSlice())
+}
+
+// checkDiff checks that the edit script reproduces both the old and new
+func checkDiff[T any](t *testing.T, a, b []T, edits []Edit[T]) {
+	old, nu := make([]T, 0), make([]T, 0)
+	for _, e := range edits {
+		if e.Op != EditInsert {
+			require.Equal(t, len(old), e.OldIndex)
+			old = append(old, e.Value)
+		}
+		if e.Op != EditDelete {
+			require.Equal(t, len(nu), e.NewIndex)
+			nu = append(nu, e.Value)
+		}
+	}
+	require.Equal(t, len(a), len(old))
+	require.Equal(t, len(b), len(nu))
+	// and that it is a shortest edit script (i.e. every element of the longest common subsequence is kept)...
+	lcs := make([]int, len(b)+1)
+	for i := range a {
+		prev := 0
+		for j := range b {
+			curr := lcs[j+1]
+			if reflect.DeepEqual(a[i], b[j]) {
+				lcs[j+1] = prev + 1
+			} else if lcs[j] > lcs[j+1] {
+				lcs[j+1] = lcs[j]
+			}
+			prev = curr
+		}
+	}
+	keeps := 0
+	for _, e := range edits {
+		if e.Op == EditKeep {
+			keeps++
+		}
+	}
+	require.Equal(t, lcs[len(b)], keeps)
+	for i := range a {
+		require.Equal(t, a[i], old[i])
+	}
+	for i := range b {
+		require.Equal(t, b[i], nu[i])
+	}
+}
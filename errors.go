@@ -0,0 +1,42 @@
+package streams
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Errors is a collection of errors - used where multiple errors are collected (rather than failing on the first error)
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the collected errors
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// ElementError is an error that occurred when processing a specific element of a stream
+type ElementError[T any] struct {
+	// Index is the index (position) of the element in the stream being processed - which, where operations are chained
+	// (e.g. a Filter followed by a ForEach), is the position after preceding operations rather than in the original source
+	Index int
+	// Value is the element that was being processed
+	Value T
+	// Err is the error that occurred
+	Err error
+}
+
+func (e *ElementError[T]) Error() string {
+	return fmt.Sprintf("element [%d]: %s", e.Index, e.Err.Error())
+}
+
+// Unwrap returns the underlying error
+func (e *ElementError[T]) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,25 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	err1 := errors.New("fooey")
+	err2 := errors.New("whoops")
+	err := Errors{err1, err2}
+	require.Equal(t, "fooey\nwhoops", err.Error())
+	require.Equal(t, []error{err1, err2}, err.Unwrap())
+}
+
+func TestElementError(t *testing.T) {
+	err1 := errors.New("fooey")
+	var err error = &ElementError[string]{Index: 2, Value: "c", Err: err1}
+	require.Equal(t, "element [2]: fooey", err.Error())
+	require.True(t, errors.Is(err, err1))
+	var eerr *ElementError[string]
+	require.True(t, errors.As(err, &eerr))
+	require.Equal(t, "c", eerr.Value)
+}
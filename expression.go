@@ -0,0 +1,345 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParsePredicate parses a filter expression into a Predicate for values of type T (which should be a struct type, or pointer
+// to struct type)
+//
+// expressions are comparisons of fields with literal values - combined using boolean operators, for example
+//  status == "open" && (priority >= 3 || assignee.name like "Bil%") && !archived
+//
+// supported...
+//   - comparison operators: ==, !=, <, <=, >, >=
+//   - boolean operators: && (or 'and'), || (or 'or'), ! (or 'not') and parentheses for grouping
+//   - membership: field in ("a", "b") and field not in (1, 2)
+//   - pattern matching: field like "abc%" and field not like "a_c" (where % matches any characters, _ matches any single character)
+//   - literals: double or single quoted strings, numbers, true, false and null
+//   - a bare field (e.g. archived) tests whether a bool field is true
+//
+// fields are struct field names (or their json tag names, matched case-insensitively) and can be nested using dots (e.g. assignee.name)
+// - pointers are followed and a field reached through a nil pointer is null - fields can also be accessors registered using RegisterAccessor
+//
+// if the expression cannot be parsed (or refers to unknown fields, or compares fields with incompatible values), a *ParseError
+// is returned
+func ParsePredicate[T any](expr string) (Predicate[T], error) {
+	p := &exprParser{
+		expr: expr,
+		t:    reflect.TypeOf((*T)(nil)).Elem(),
+	}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	cond, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEnd {
+		err = p.unexpected(p.peek())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conditionPredicate[T](cond), nil
+}
+
+// ParseError is the error returned by ParsePredicate when an expression cannot be parsed
+type ParseError struct {
+	Expr string // the expression being parsed
+	Pos  int    // the position (zero based byte offset) in the expression at which the error occurred
+	Msg  string // the error message
+}
+
+// Error implements error interface
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error at position %d: %s", e.Pos, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type exprToken struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+func (t exprToken) String() string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of expression"
+	case tokenIdent:
+		return fmt.Sprintf("identifier %q", t.text)
+	case tokenString:
+		return fmt.Sprintf("string %s", t.text)
+	case tokenNumber:
+		return fmt.Sprintf("number %s", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// is determines whether the token is the specified operator or (case-insensitive) keyword
+func (t exprToken) is(s string) bool {
+	return (t.kind == tokenOperator && t.text == s) || (t.kind == tokenIdent && strings.EqualFold(t.text, s))
+}
+
+type exprParser struct {
+	expr   string
+	t      reflect.Type
+	tokens []exprToken
+	curr   int
+}
+
+var exprOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", ","}
+
+func (p *exprParser) tokenize() error {
+	rs := []rune(p.expr)
+	pos := 0
+	offset := func(i int) int {
+		return len(string(rs[:i]))
+	}
+	for pos < len(rs) {
+		ch := rs[pos]
+		start := pos
+		switch {
+		case unicode.IsSpace(ch):
+			pos++
+			continue
+		case ch == '"' || ch == '\'':
+			var sb strings.Builder
+			pos++
+			for ; pos < len(rs) && rs[pos] != ch; pos++ {
+				if rs[pos] == '\\' && pos+1 < len(rs) {
+					pos++
+					switch rs[pos] {
+					case 'n':
+						sb.WriteRune('\n')
+					case 't':
+						sb.WriteRune('\t')
+					default:
+						sb.WriteRune(rs[pos])
+					}
+				} else {
+					sb.WriteRune(rs[pos])
+				}
+			}
+			if pos >= len(rs) {
+				return p.error(offset(start), "unterminated string")
+			}
+			pos++
+			p.tokens = append(p.tokens, exprToken{kind: tokenString, text: string(rs[start:pos]), value: sb.String(), pos: offset(start)})
+			continue
+		case unicode.IsDigit(ch) || (ch == '-' && pos+1 < len(rs) && (unicode.IsDigit(rs[pos+1]) || rs[pos+1] == '.')) || ch == '.':
+			pos++
+			for pos < len(rs) && (unicode.IsDigit(rs[pos]) || rs[pos] == '.' || rs[pos] == 'e' || rs[pos] == 'E' ||
+				((rs[pos] == '-' || rs[pos] == '+') && (rs[pos-1] == 'e' || rs[pos-1] == 'E'))) {
+				pos++
+			}
+			text := string(rs[start:pos])
+			var value any
+			if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+				value = i
+			} else if errors.Is(err, strconv.ErrRange) && !strings.ContainsAny(text, ".eE") {
+				return p.error(offset(start), fmt.Sprintf("integer out of range %q", text))
+			} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+				value = f
+			} else {
+				return p.error(offset(start), fmt.Sprintf("invalid number %q", text))
+			}
+			p.tokens = append(p.tokens, exprToken{kind: tokenNumber, text: text, value: value, pos: offset(start)})
+			continue
+		case unicode.IsLetter(ch) || ch == '_':
+			for pos < len(rs) && (unicode.IsLetter(rs[pos]) || unicode.IsDigit(rs[pos]) || rs[pos] == '_' || rs[pos] == '.') {
+				pos++
+			}
+			p.tokens = append(p.tokens, exprToken{kind: tokenIdent, text: string(rs[start:pos]), pos: offset(start)})
+			continue
+		}
+		matched := false
+		for _, op := range exprOperators {
+			if strings.HasPrefix(string(rs[pos:]), op) {
+				p.tokens = append(p.tokens, exprToken{kind: tokenOperator, text: op, pos: offset(start)})
+				pos += len([]rune(op))
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return p.error(offset(start), fmt.Sprintf("unexpected character %q", ch))
+		}
+	}
+	p.tokens = append(p.tokens, exprToken{kind: tokenEnd, pos: len(p.expr)})
+	return nil
+}
+
+func (p *exprParser) error(pos int, msg string) *ParseError {
+	return &ParseError{
+		Expr: p.expr,
+		Pos:  pos,
+		Msg:  msg,
+	}
+}
+
+func (p *exprParser) unexpected(t exprToken) *ParseError {
+	return p.error(t.pos, "unexpected "+t.String())
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.curr]
+}
+
+func (p *exprParser) next() exprToken {
+	t := p.tokens[p.curr]
+	if t.kind != tokenEnd {
+		p.curr++
+	}
+	return t
+}
+
+func (p *exprParser) expect(s string) error {
+	if t := p.next(); !t.is(s) {
+		return p.error(t.pos, fmt.Sprintf("expected %q but found %s", s, t))
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (func(v reflect.Value) bool, error) {
+	left, err := p.parseAnd()
+	for err == nil && (p.peek().is("||") || p.peek().is("or")) {
+		p.next()
+		var right func(v reflect.Value) bool
+		if right, err = p.parseAnd(); err == nil {
+			l := left
+			left = func(v reflect.Value) bool {
+				return l(v) || right(v)
+			}
+		}
+	}
+	return left, err
+}
+
+func (p *exprParser) parseAnd() (func(v reflect.Value) bool, error) {
+	left, err := p.parseUnary()
+	for err == nil && (p.peek().is("&&") || p.peek().is("and")) {
+		p.next()
+		var right func(v reflect.Value) bool
+		if right, err = p.parseUnary(); err == nil {
+			l := left
+			left = func(v reflect.Value) bool {
+				return l(v) && right(v)
+			}
+		}
+	}
+	return left, err
+}
+
+func (p *exprParser) parseUnary() (func(v reflect.Value) bool, error) {
+	if p.peek().is("!") || p.peek().is("not") {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) bool {
+			return !inner(v)
+		}, nil
+	}
+	return p.parsePrimary()
+}
+
+var exprKeywords = map[string]bool{"and": true, "or": true, "not": true, "in": true, "like": true, "true": true, "false": true, "null": true}
+
+func (p *exprParser) parsePrimary() (func(v reflect.Value) bool, error) {
+	t := p.next()
+	if t.is("(") {
+		inner, err := p.parseOr()
+		if err == nil {
+			err = p.expect(")")
+		}
+		return inner, err
+	} else if t.kind != tokenIdent || exprKeywords[strings.ToLower(t.text)] {
+		return nil, p.unexpected(t)
+	}
+	field := t
+	op := opEq
+	var value any = true
+	var err error
+	switch nt := p.peek(); {
+	case nt.kind == tokenOperator && (nt.text == "==" || nt.text == "!=" || nt.text == "<" || nt.text == "<=" || nt.text == ">" || nt.text == ">="):
+		p.next()
+		op = nt.text
+		value, err = p.parseLiteral()
+	case nt.is("in"), nt.is("like"):
+		p.next()
+		op, value, err = p.parseMatch(strings.ToLower(nt.text))
+	case nt.is("not"):
+		p.next()
+		mt := p.next()
+		if !mt.is("in") && !mt.is("like") {
+			return nil, p.error(mt.pos, fmt.Sprintf("expected \"in\" or \"like\" but found %s", mt))
+		}
+		op, value, err = p.parseMatch(strings.ToLower(mt.text))
+		op = "not " + op
+	}
+	if err != nil {
+		return nil, err
+	}
+	cond, err := newCondition(p.t, field.text, op, value, false)
+	if err != nil {
+		return nil, p.error(field.pos, err.Error())
+	}
+	return cond, nil
+}
+
+func (p *exprParser) parseMatch(op string) (string, any, error) {
+	if op == opLike {
+		t := p.next()
+		if t.kind != tokenString {
+			return op, nil, p.error(t.pos, fmt.Sprintf("expected string but found %s", t))
+		}
+		return op, t.value, nil
+	}
+	if err := p.expect("("); err != nil {
+		return op, nil, err
+	}
+	list := make([]any, 0)
+	for {
+		v, err := p.parseLiteral()
+		if err != nil {
+			return op, nil, err
+		}
+		list = append(list, v)
+		if t := p.next(); t.is(")") {
+			break
+		} else if !t.is(",") {
+			return op, nil, p.error(t.pos, fmt.Sprintf("expected \",\" or \")\" but found %s", t))
+		}
+	}
+	return op, list, nil
+}
+
+func (p *exprParser) parseLiteral() (any, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString || t.kind == tokenNumber:
+		return t.value, nil
+	case t.is("true"):
+		return true, nil
+	case t.is("false"):
+		return false, nil
+	case t.is("null"):
+		return nil, nil
+	}
+	return nil, p.error(t.pos, fmt.Sprintf("expected value but found %s", t))
+}
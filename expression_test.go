@@ -0,0 +1,170 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type exprUser struct {
+	Name string
+	Age  int
+}
+
+type exprIssue struct {
+	Status   string `json:"status"`
+	Priority int
+	Score    float64
+	Archived bool
+	Labels   []string
+	Assignee *exprUser
+	Due      time.Time
+	Extra    any
+	Meta     map[string]string
+	secret   string
+}
+
+func TestParsePredicate(t *testing.T) {
+	due := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := []exprIssue{
+		{Status: "open", Priority: 3, Score: 1.5, Assignee: &exprUser{Name: "Bilbo", Age: 111}, Due: due, Meta: map[string]string{"team": "a"}},
+		{Status: "open", Priority: 1, Score: 2.5, Archived: true, Extra: exprUser{Name: "Frodo"}},
+		{Status: "closed", Priority: 5, Score: 0, Assignee: &exprUser{Name: "Sam", Age: 38}, Due: due.AddDate(0, 1, 0)},
+		{Status: "Open", Priority: 4, secret: "x"},
+	}
+	testCases := []struct {
+		expr   string
+		expect []int
+	}{
+		{`status == "open"`, []int{0, 1}},
+		{`Status == 'open' && Priority >= 3`, []int{0}},
+		{`status == "open" and priority >= 3 or priority == 5`, []int{0, 2}},
+		{`status == "open" && (priority >= 3 || priority == 1)`, []int{0, 1}},
+		{`!(status == "open")`, []int{2, 3}},
+		{`not status == "open"`, []int{2, 3}},
+		{`status != "open"`, []int{2, 3}},
+		{`priority < 3`, []int{1}},
+		{`priority <= 3`, []int{0, 1}},
+		{`priority > 3`, []int{2, 3}},
+		{`score > 1`, []int{0, 1}},
+		{`score >= 1.5 && score < 2.5`, []int{0}},
+		{`priority > 2.5`, []int{0, 2, 3}},
+		{`score < 9223372036854775808.0 && score < 1e30`, []int{0, 1, 2, 3}},
+		{`priority in (1, 5)`, []int{1, 2}},
+		{`priority not in (1, 5)`, []int{0, 3}},
+		{`status IN ("closed", "Open")`, []int{2, 3}},
+		{`status like "op%"`, []int{0, 1}},
+		{`status like "_pen"`, []int{0, 1, 3}},
+		{`status not like "op%"`, []int{2, 3}},
+		{`archived`, []int{1}},
+		{`!archived && priority > 1`, []int{0, 2, 3}},
+		{`archived == false`, []int{0, 2, 3}},
+		{`assignee.name == "Bilbo"`, []int{0}},
+		{`assignee.age > 50`, []int{0}},
+		{`assignee == null`, []int{1, 3}},
+		{`assignee != null`, []int{0, 2}},
+		{`assignee.name == null`, []int{1, 3}},
+		{`assignee.name in ("Sam", null)`, []int{1, 2, 3}},
+		{`extra.name == "Frodo"`, []int{1}},
+		{`extra.unknown == "Frodo"`, []int{}},
+		{`labels == null`, []int{0, 1, 2, 3}},
+		{`due < "2024-06-15T00:00:00Z"`, []int{0, 1, 3}},
+		{`due > "2024-06-15T00:00:00Z"`, []int{2}},
+		{`meta.team == "a"`, []int{0}},
+		{`status == "a\"b"`, []int{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			p, err := ParsePredicate[exprIssue](tc.expr)
+			require.NoError(t, err)
+			matched := make([]int, 0)
+			for i, v := range issues {
+				if p.Test(v) {
+					matched = append(matched, i)
+				}
+			}
+			require.Equal(t, tc.expect, matched)
+		})
+	}
+}
+
+func TestParsePredicate_Pointers(t *testing.T) {
+	p, err := ParsePredicate[*exprIssue](`assignee.name like "B%"`)
+	require.NoError(t, err)
+	require.True(t, p.Test(&exprIssue{Assignee: &exprUser{Name: "Bilbo"}}))
+	require.False(t, p.Test(&exprIssue{}))
+	require.False(t, p.Test(nil))
+	s := Of(&exprIssue{Assignee: &exprUser{Name: "Bilbo"}}, nil, &exprIssue{Assignee: &exprUser{Name: "Sam"}})
+	require.Equal(t, 1, s.Count(p))
+}
+
+func TestParsePredicate_RegisteredAccessor(t *testing.T) {
+	RegisterAccessor[exprIssue]("secret", func(v exprIssue) any {
+		return v.secret
+	})
+	RegisterAccessor[exprUser]("initial", func(v exprUser) any {
+		return v.Name[:1]
+	})
+	defer RegisterAccessor[exprIssue]("secret", nil)
+	defer RegisterAccessor[exprUser]("initial", nil)
+	p, err := ParsePredicate[exprIssue](`secret == "x"`)
+	require.NoError(t, err)
+	require.True(t, p.Test(exprIssue{secret: "x"}))
+	require.False(t, p.Test(exprIssue{}))
+	p, err = ParsePredicate[exprIssue](`assignee.initial == "B"`)
+	require.NoError(t, err)
+	require.True(t, p.Test(exprIssue{Assignee: &exprUser{Name: "Bilbo"}}))
+	require.False(t, p.Test(exprIssue{}))
+}
+
+func TestParsePredicate_Errors(t *testing.T) {
+	testCases := []struct {
+		expr   string
+		pos    int
+		expect string
+	}{
+		{``, 0, `unexpected end of expression`},
+		{`status ==`, 9, `expected value but found end of expression`},
+		{`status == "open`, 10, `unterminated string`},
+		{`status = "open"`, 7, `unexpected character '='`},
+		{`status == "open" &&`, 19, `unexpected end of expression`},
+		{`status == "open" "closed"`, 17, `unexpected string "closed"`},
+		{`(status == "open"`, 17, `expected ")" but found end of expression`},
+		{`foo == 1`, 0, `unknown field "foo"`},
+		{`priority == 1 && assignee.foo == 1`, 17, `unknown field "assignee.foo"`},
+		{`status == 1`, 0, `cannot compare field "status" (string) with 1`},
+		{`priority > "x"`, 0, `cannot compare field "priority" (int) with "x"`},
+		{`priority > null`, 0, `operator ">" requires a non-null value`},
+		{`priority like "x"`, 0, `operator "like" cannot be used with field "priority" (int)`},
+		{`status like 1`, 12, `expected string but found number 1`},
+		{`status in "a"`, 10, `expected "(" but found string "a"`},
+		{`status in ("a" "b")`, 15, `expected "," or ")" but found string "b"`},
+		{`status not between 1`, 11, `expected "in" or "like" but found identifier "between"`},
+		{`status`, 0, `cannot compare field "status" (string) with true`},
+		{`and == 1`, 0, `unexpected identifier "and"`},
+		{`priority == 1.2.3`, 12, `invalid number "1.2.3"`},
+		{`priority == 9223372036854775808`, 12, `integer out of range "9223372036854775808"`},
+		{`priority == -9223372036854775809`, 12, `integer out of range "-9223372036854775809"`},
+		{`secret == "x"`, 0, `unknown field "secret"`},
+		{`priority.x == 1`, 0, `cannot access field "priority.x" of int`},
+		{`Ünïcödé == 1 && x`, 0, `unknown field "Ünïcödé"`},
+		{`"Ünïcödé" == 1 &`, 19, `unexpected character '&'`},
+		{`"Ünïcödé" == 1`, 0, `unexpected string "Ünïcödé"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := ParsePredicate[exprIssue](tc.expr)
+			require.Error(t, err)
+			pe, ok := err.(*ParseError)
+			require.True(t, ok)
+			require.Equal(t, tc.expr, pe.Expr)
+			require.Equal(t, tc.expect, pe.Msg)
+			require.Equal(t, tc.pos, pe.Pos)
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	err := &ParseError{Expr: "x", Pos: 3, Msg: "foo"}
+	require.Equal(t, "parse error at position 3: foo", err.Error())
+}
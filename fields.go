@@ -0,0 +1,552 @@
+package streams
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RegisterAccessor registers a named accessor for values of type T - which can then be used as a field name in
+// field paths (e.g. in ParsePredicate expressions)
+//
+// accessors are useful for exposing computed values or unexported fields - for example
+//  RegisterAccessor[Person]("fullName", func(v Person) any { return v.first + " " + v.last })
+//
+// the type T should be the (non-pointer) type - when a field path is resolved, pointers are dereferenced before
+// accessors are looked up
+//
+// registered accessors take precedence over struct fields of the same name
+func RegisterAccessor[T any](name string, f func(v T) any) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	accessors.Lock()
+	defer accessors.Unlock()
+	if f == nil {
+		delete(accessors.m[t], name)
+		return
+	}
+	if accessors.m[t] == nil {
+		accessors.m[t] = map[string]func(reflect.Value) reflect.Value{}
+	}
+	accessors.m[t][name] = func(v reflect.Value) reflect.Value {
+		return reflect.ValueOf(f(v.Interface().(T)))
+	}
+	// previously resolved dynamic paths may now resolve differently...
+	dynamicGetters.Range(func(key, _ any) bool {
+		dynamicGetters.Delete(key)
+		return true
+	})
+}
+
+var accessors = struct {
+	sync.RWMutex
+	m map[reflect.Type]map[string]func(reflect.Value) reflect.Value
+}{
+	m: map[reflect.Type]map[string]func(reflect.Value) reflect.Value{},
+}
+
+func lookupAccessor(t reflect.Type, name string) (func(reflect.Value) reflect.Value, bool) {
+	accessors.RLock()
+	defer accessors.RUnlock()
+	f, ok := accessors.m[t][name]
+	return f, ok
+}
+
+var (
+	anyType  = reflect.TypeOf((*any)(nil)).Elem()
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// typeFields is the cached reflection metadata for a struct type
+type typeFields struct {
+	byName map[string]*fieldInfo
+	fields []*fieldInfo
+}
+
+type fieldInfo struct {
+	field  reflect.StructField
+	tag    fieldTag
+	tagErr error
+}
+
+var typeFieldsCache sync.Map
+
+// fieldsOf returns the (cached) reflection metadata for the exported fields of a struct type
+func fieldsOf(t reflect.Type) *typeFields {
+	if tf, ok := typeFieldsCache.Load(t); ok {
+		return tf.(*typeFields)
+	}
+	tf := &typeFields{
+		byName: map[string]*fieldInfo{},
+	}
+	lower := map[string]*fieldInfo{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		fi := &fieldInfo{
+			field: f,
+		}
+		fi.tag, fi.tagErr = parseFieldTag(f.Tag.Get(TagName))
+		tf.fields = append(tf.fields, fi)
+		tf.byName[f.Name] = fi
+		if jn, _, _ := strings.Cut(f.Tag.Get("json"), ","); jn != "" && jn != "-" {
+			lower[strings.ToLower(jn)] = fi
+		}
+		if _, exists := lower[strings.ToLower(f.Name)]; !exists {
+			lower[strings.ToLower(f.Name)] = fi
+		}
+	}
+	for n, fi := range lower {
+		if _, exists := tf.byName[n]; !exists {
+			tf.byName[n] = fi
+		}
+	}
+	actual, _ := typeFieldsCache.LoadOrStore(t, tf)
+	return actual.(*typeFields)
+}
+
+// field finds a field by name - matching the Go field name exactly, otherwise the json tag name or Go field name case-insensitively
+func (tf *typeFields) field(name string) (*fieldInfo, bool) {
+	if fi, ok := tf.byName[name]; ok {
+		return fi, true
+	}
+	fi, ok := tf.byName[strings.ToLower(name)]
+	return fi, ok
+}
+
+// fieldGetter gets a field value from a value - returning false if the field cannot be reached (e.g. through a nil pointer)
+type fieldGetter func(v reflect.Value) (reflect.Value, bool)
+
+// newFieldGetter resolves a (dot separated) field path against a type - returning the getter and the static type of the field
+//
+// where the path passes through an interface (or a registered accessor), the remainder of the path is resolved
+// dynamically - and the returned type is the any type
+func newFieldGetter(t reflect.Type, path string) (fieldGetter, reflect.Type, error) {
+	segs := strings.Split(path, ".")
+	steps := make([]fieldGetter, 0, len(segs))
+	curr := t
+	for i := 0; i < len(segs); i++ {
+		seg := segs[i]
+		if seg == "" {
+			return nil, nil, fmt.Errorf("invalid field path %q", path)
+		}
+		curr = derefType(curr)
+		if acc, ok := lookupAccessor(curr, seg); ok {
+			steps = append(steps, func(v reflect.Value) (reflect.Value, bool) {
+				return acc(v), true
+			})
+			curr = anyType
+			continue
+		}
+		switch curr.Kind() {
+		case reflect.Struct:
+			fi, ok := fieldsOf(curr).field(seg)
+			if !ok {
+				return nil, nil, fmt.Errorf("unknown field %q", strings.Join(segs[:i+1], "."))
+			}
+			idx := fi.field.Index
+			steps = append(steps, func(v reflect.Value) (reflect.Value, bool) {
+				fv, err := v.FieldByIndexErr(idx)
+				return fv, err == nil
+			})
+			curr = fi.field.Type
+		case reflect.Map:
+			if curr.Key().Kind() != reflect.String {
+				return nil, nil, fmt.Errorf("cannot access field %q of %s", strings.Join(segs[:i+1], "."), curr)
+			}
+			key := reflect.ValueOf(seg).Convert(curr.Key())
+			steps = append(steps, func(v reflect.Value) (reflect.Value, bool) {
+				mv := v.MapIndex(key)
+				return mv, mv.IsValid()
+			})
+			curr = curr.Elem()
+		case reflect.Interface:
+			rest := strings.Join(segs[i:], ".")
+			steps = append(steps, func(v reflect.Value) (reflect.Value, bool) {
+				if g, err := dynamicGetter(v.Type(), rest); err == nil {
+					return g(v)
+				}
+				return reflect.Value{}, false
+			})
+			curr = anyType
+			i = len(segs)
+		default:
+			return nil, nil, fmt.Errorf("cannot access field %q of %s", strings.Join(segs[:i+1], "."), curr)
+		}
+	}
+	return func(v reflect.Value) (reflect.Value, bool) {
+		for _, step := range steps {
+			var ok bool
+			if v, ok = derefValue(v); !ok {
+				return v, false
+			}
+			if v, ok = step(v); !ok {
+				return v, false
+			}
+		}
+		return v, true
+	}, curr, nil
+}
+
+type dynamicGetterKey struct {
+	t    reflect.Type
+	path string
+}
+
+var dynamicGetters sync.Map
+
+func dynamicGetter(t reflect.Type, path string) (fieldGetter, error) {
+	key := dynamicGetterKey{t: t, path: path}
+	if g, ok := dynamicGetters.Load(key); ok {
+		return g.(fieldGetter), nil
+	}
+	g, _, err := newFieldGetter(t, path)
+	if err != nil {
+		return nil, err
+	}
+	dynamicGetters.Store(key, g)
+	return g, nil
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// derefValue dereferences pointers and interfaces - returning false if nil is encountered
+func derefValue(v reflect.Value) (reflect.Value, bool) {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+// compareValues compares two values dynamically - returning false if the values cannot be ordered
+//
+// numeric values of any kinds can be compared, and time values can be compared with RFC3339 strings
+//
+// if fold is true, strings are compared case-insensitively
+func compareValues(a, b reflect.Value, fold bool) (int, bool) {
+	var ok bool
+	if a, ok = derefValue(a); !ok {
+		return 0, false
+	}
+	if b, ok = derefValue(b); !ok {
+		return 0, false
+	}
+	switch {
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return compareOrdered(a.Int(), b.Int()), true
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return compareOrdered(a.Uint(), b.Uint()), true
+	case isNumericKind(a.Kind()) && isNumericKind(b.Kind()):
+		return compareOrdered(toFloat(a), toFloat(b)), true
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		if fold {
+			return strings.Compare(strings.ToLower(a.String()), strings.ToLower(b.String())), true
+		}
+		return strings.Compare(a.String(), b.String()), true
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		return compareOrdered(boolInt(a.Bool()), boolInt(b.Bool())), true
+	case a.Type() == timeType || b.Type() == timeType:
+		ta, aOk := toTime(a)
+		tb, bOk := toTime(b)
+		if aOk && bOk {
+			if ta.Before(tb) {
+				return -1, true
+			} else if ta.After(tb) {
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// equalValues determines whether two values are equal - using compareValues where the values can be ordered
+func equalValues(a, b reflect.Value, fold bool) bool {
+	if c, ok := compareValues(a, b, fold); ok {
+		return c == 0
+	}
+	a, aOk := derefValue(a)
+	b, bOk := derefValue(b)
+	if !aOk || !bOk {
+		return aOk == bOk
+	}
+	return a.CanInterface() && b.CanInterface() && reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// isNullValue determines whether a field value is null (i.e. unreachable, nil or a nil pointer etc.)
+func isNullValue(v reflect.Value, ok bool) bool {
+	if !ok || !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	}
+	return false
+}
+
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isIntKind(v.Kind()):
+		return float64(v.Int())
+	case isUintKind(v.Kind()):
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+func toTime(v reflect.Value) (time.Time, bool) {
+	if v.Type() == timeType && v.CanInterface() {
+		return v.Interface().(time.Time), true
+	} else if v.Kind() == reflect.String {
+		if t, err := time.Parse(time.RFC3339, v.String()); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// comparableKinds determines (statically) whether values of the field type can be compared with values of the value type
+func comparableKinds(ft, vt reflect.Type) bool {
+	ft, vt = derefType(ft), derefType(vt)
+	switch {
+	case ft.Kind() == reflect.Interface || vt.Kind() == reflect.Interface:
+		return true
+	case isNumericKind(ft.Kind()):
+		return isNumericKind(vt.Kind())
+	case ft == timeType:
+		return vt == timeType || vt.Kind() == reflect.String
+	case ft.Kind() == reflect.String:
+		return vt.Kind() == reflect.String
+	case ft.Kind() == reflect.Bool:
+		return vt.Kind() == reflect.Bool
+	}
+	return ft == vt
+}
+
+// condition operators - each has a symbolic and a named form
+const (
+	opEq      = "=="
+	opNe      = "!="
+	opLt      = "<"
+	opLte     = "<="
+	opGt      = ">"
+	opGte     = ">="
+	opIn      = "in"
+	opNotIn   = "not in"
+	opLike    = "like"
+	opNotLike = "not like"
+)
+
+var conditionOps = map[string]string{
+	opEq: opEq, "=": opEq, "eq": opEq,
+	opNe: opNe, "<>": opNe, "ne": opNe, "neq": opNe,
+	opLt: opLt, "lt": opLt,
+	opLte: opLte, "lte": opLte, "le": opLte,
+	opGt: opGt, "gt": opGt,
+	opGte: opGte, "gte": opGte, "ge": opGte,
+	opIn: opIn, opNotIn: opNotIn, "nin": opNotIn,
+	opLike: opLike, opNotLike: opNotLike,
+}
+
+// newCondition creates a condition function that tests a field of values of the type against the value using the operator
+//
+// fold indicates string comparisons are case-insensitive
+func newCondition(t reflect.Type, field string, op string, value any, fold bool) (func(v reflect.Value) bool, error) {
+	nop, ok := conditionOps[strings.ToLower(op)]
+	if !ok {
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+	getter, ft, err := newFieldGetter(t, field)
+	if err != nil {
+		return nil, err
+	}
+	switch nop {
+	case opEq, opNe:
+		negate := nop == opNe
+		if value == nil {
+			return func(v reflect.Value) bool {
+				return isNullValue(getter(v)) != negate
+			}, nil
+		}
+		rv := reflect.ValueOf(value)
+		if !comparableKinds(ft, rv.Type()) {
+			return nil, fmt.Errorf("cannot compare field %q (%s) with %#v", field, ft, value)
+		}
+		return func(v reflect.Value) bool {
+			fv, ok := getter(v)
+			return (!isNullValue(fv, ok) && equalValues(fv, rv, fold)) != negate
+		}, nil
+	case opLt, opLte, opGt, opGte:
+		if value == nil {
+			return nil, fmt.Errorf("operator %q requires a non-null value", op)
+		}
+		rv := reflect.ValueOf(value)
+		if !comparableKinds(ft, rv.Type()) {
+			return nil, fmt.Errorf("cannot compare field %q (%s) with %#v", field, ft, value)
+		}
+		test := map[string]func(c int) bool{
+			opLt:  func(c int) bool { return c < 0 },
+			opLte: func(c int) bool { return c <= 0 },
+			opGt:  func(c int) bool { return c > 0 },
+			opGte: func(c int) bool { return c >= 0 },
+		}[nop]
+		return func(v reflect.Value) bool {
+			fv, ok := getter(v)
+			if isNullValue(fv, ok) {
+				return false
+			}
+			c, ok := compareValues(fv, rv, fold)
+			return ok && test(c)
+		}, nil
+	case opIn, opNotIn:
+		negate := nop == opNotIn
+		lv := reflect.ValueOf(value)
+		if !lv.IsValid() || (lv.Kind() != reflect.Slice && lv.Kind() != reflect.Array) {
+			return nil, fmt.Errorf("operator %q requires a list value", op)
+		}
+		list := make([]reflect.Value, lv.Len())
+		for i := range list {
+			list[i] = lv.Index(i)
+			if ev, ok := derefValue(list[i]); ok && !comparableKinds(ft, ev.Type()) {
+				return nil, fmt.Errorf("cannot compare field %q (%s) with %#v", field, ft, ev.Interface())
+			}
+		}
+		return func(v reflect.Value) bool {
+			fv, ok := getter(v)
+			null := isNullValue(fv, ok)
+			for _, lv := range list {
+				if null {
+					if _, ok := derefValue(lv); !ok {
+						return !negate
+					}
+				} else if equalValues(fv, lv, fold) {
+					return !negate
+				}
+			}
+			return negate
+		}, nil
+	}
+	// like / not like...
+	negate := nop == opNotLike
+	pattern, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("operator %q requires a string value", op)
+	}
+	if k := derefType(ft).Kind(); k != reflect.String && k != reflect.Interface {
+		return nil, fmt.Errorf("operator %q cannot be used with field %q (%s)", op, field, ft)
+	}
+	re := likeRegexp(pattern, fold)
+	return func(v reflect.Value) bool {
+		fv, ok := getter(v)
+		if fv, ok = derefValue(fv); ok && fv.Kind() == reflect.String {
+			return re.MatchString(fv.String()) != negate
+		}
+		return false
+	}, nil
+}
+
+// likeRegexp converts an SQL style like pattern (where % matches any sequence of characters and _ matches any single character)
+// to a regular expression
+func likeRegexp(pattern string, fold bool) *regexp.Regexp {
+	var sb strings.Builder
+	if fold {
+		sb.WriteString("(?i)")
+	}
+	sb.WriteString("(?s)^")
+	for _, ch := range pattern {
+		switch ch {
+		case '%':
+			sb.WriteString(".*")
+		case '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+// conditionPredicate creates a Predicate[T] from a condition function
+func conditionPredicate[T any](cond func(v reflect.Value) bool) Predicate[T] {
+	return NewPredicate(func(v T) bool {
+		return cond(reflect.ValueOf(&v).Elem())
+	})
+}
+
+// newFieldComparison creates a comparison function that compares a field of values of the type
+//
+// null values are ordered before or after all non-null values (regardless of descending) according to nullsFirst
+func newFieldComparison(t reflect.Type, field string, descending bool, nullsFirst bool, fold bool) (func(a, b reflect.Value) int, error) {
+	getter, ft, err := newFieldGetter(t, field)
+	if err != nil {
+		return nil, err
+	}
+	switch k := derefType(ft).Kind(); {
+	case isNumericKind(k), k == reflect.String, k == reflect.Bool, k == reflect.Interface, derefType(ft) == timeType:
+	default:
+		return nil, fmt.Errorf("field %q (%s) is not sortable", field, ft)
+	}
+	nulls := 1
+	if nullsFirst {
+		nulls = -1
+	}
+	return func(a, b reflect.Value) int {
+		av, aOk := getter(a)
+		bv, bOk := getter(b)
+		aNull, bNull := isNullValue(av, aOk), isNullValue(bv, bOk)
+		switch {
+		case aNull && bNull:
+			return 0
+		case aNull:
+			return nulls
+		case bNull:
+			return -nulls
+		}
+		c, _ := compareValues(av, bv, fold)
+		if descending {
+			return -c
+		}
+		return c
+	}, nil
+}
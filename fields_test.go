@@ -0,0 +1,108 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompareValues(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		a, b   any
+		fold   bool
+		expect int
+		ok     bool
+	}{
+		{1, 2, false, -1, true},
+		{int8(2), int64(2), false, 0, true},
+		{uint(3), uint8(2), false, 1, true},
+		{uint(3), 4, false, -1, true},
+		{1.5, 1, false, 1, true},
+		{"a", "b", false, -1, true},
+		{"B", "a", false, -1, true},
+		{"B", "a", true, 1, true},
+		{false, true, false, -1, true},
+		{now, now.Add(time.Second), false, -1, true},
+		{now, now.Add(-time.Second).Format(time.RFC3339Nano), false, 1, true},
+		{now, "not a time", false, 0, false},
+		{"a", 1, false, 0, false},
+		{nil, 1, false, 0, false},
+		{1, nil, false, 0, false},
+		{[]int{1}, []int{1}, false, 0, false},
+	}
+	for _, tc := range testCases {
+		c, ok := compareValues(reflect.ValueOf(tc.a), reflect.ValueOf(tc.b), tc.fold)
+		require.Equal(t, tc.ok, ok)
+		require.Equal(t, tc.expect, c)
+	}
+}
+
+func TestEqualValues(t *testing.T) {
+	require.True(t, equalValues(reflect.ValueOf(1), reflect.ValueOf(int64(1)), false))
+	require.True(t, equalValues(reflect.ValueOf("A"), reflect.ValueOf("a"), true))
+	require.False(t, equalValues(reflect.ValueOf("A"), reflect.ValueOf("a"), false))
+	require.True(t, equalValues(reflect.ValueOf([]int{1}), reflect.ValueOf([]int{1}), false))
+	require.False(t, equalValues(reflect.ValueOf([]int{1}), reflect.ValueOf([]int{2}), false))
+	require.True(t, equalValues(reflect.ValueOf(nil), reflect.ValueOf(nil), false))
+	require.False(t, equalValues(reflect.ValueOf(nil), reflect.ValueOf(1), false))
+}
+
+func TestLikeRegexp(t *testing.T) {
+	re := likeRegexp("a%b_c.", false)
+	require.True(t, re.MatchString("ab_c."))
+	require.True(t, re.MatchString("axxxbxc."))
+	require.False(t, re.MatchString("axxxbxcx"))
+	require.False(t, re.MatchString("Axxxbxc."))
+	re = likeRegexp("a%", true)
+	require.True(t, re.MatchString("Abc"))
+	require.True(t, re.MatchString("a\nb"))
+}
+
+func TestNewCondition(t *testing.T) {
+	it := reflect.TypeOf(exprIssue{})
+	_, err := newCondition(it, "status", "between", 1, false)
+	require.EqualError(t, err, `unknown operator "between"`)
+	_, err = newCondition(it, "status", "in", "a", false)
+	require.EqualError(t, err, `operator "in" requires a list value`)
+	_, err = newCondition(it, "status", "in", []any{1}, false)
+	require.EqualError(t, err, `cannot compare field "status" (string) with 1`)
+	_, err = newCondition(it, "status", "like", 1, false)
+	require.EqualError(t, err, `operator "like" requires a string value`)
+	_, err = newCondition(it, "assignee..name", "eq", 1, false)
+	require.EqualError(t, err, `invalid field path "assignee..name"`)
+	_, err = newCondition(reflect.TypeOf(map[int]string{}), "x", "eq", 1, false)
+	require.EqualError(t, err, `cannot access field "x" of map[int]string`)
+
+	cond, err := newCondition(it, "Status", "EQ", "OPEN", true)
+	require.NoError(t, err)
+	require.True(t, cond(reflect.ValueOf(exprIssue{Status: "open"})))
+	cond, err = newCondition(it, "priority", "nin", []int{1, 2}, false)
+	require.NoError(t, err)
+	require.True(t, cond(reflect.ValueOf(exprIssue{Priority: 3})))
+	require.False(t, cond(reflect.ValueOf(exprIssue{Priority: 2})))
+}
+
+func TestFieldsOf(t *testing.T) {
+	type embedded struct {
+		Inner string
+	}
+	type st struct {
+		embedded
+		Name   string `json:"full_name"`
+		Hidden string `json:"-"`
+		lower  string
+	}
+	tf := fieldsOf(reflect.TypeOf(st{}))
+	require.Same(t, tf, fieldsOf(reflect.TypeOf(st{})))
+	require.Equal(t, 3, len(tf.fields))
+	for _, n := range []string{"Name", "name", "full_name", "FULL_NAME", "Inner", "inner", "Hidden", "hidden"} {
+		_, ok := tf.field(n)
+		require.True(t, ok, n)
+	}
+	for _, n := range []string{"lower", "embedded", "-"} {
+		_, ok := tf.field(n)
+		require.False(t, ok, n)
+	}
+}
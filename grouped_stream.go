@@ -0,0 +1,222 @@
+package streams
+
+import (
+	"reflect"
+	"sort"
+)
+
+// GroupedStream is a stream that has been grouped by key (see GroupBy) - where each group is itself a Stream
+//
+// groups are in order of first encounter of each key (unless re-ordered using SortedKeys)
+//
+// reducing and mapping of groups (where the result type differs) are provided by the ReduceGroups and MapGroups functions
+type GroupedStream[K comparable, T any] interface {
+	// Count returns a stream of pairs of each key and the number of elements in its group
+	Count() Stream[Pair[K, int]]
+	// Flatten returns a stream of all the elements of all groups (in group order)
+	Flatten() Stream[T]
+	// Get returns the group for the specified key
+	//
+	// if there is no group for the key, an empty stream is returned
+	Get(k K) Stream[T]
+	// Groups returns a stream of pairs of each key and its group
+	Groups() Stream[Pair[K, Stream[T]]]
+	// Has returns whether there is a group for the specified key
+	Has(k K) bool
+	// Keys returns a stream of the keys
+	Keys() Stream[K]
+	// Len returns the number of groups
+	Len() int
+	// SortedKeys returns a new GroupedStream with the groups ordered by key according to the provided comparator
+	//
+	// if the provided comparator is nil, the groups are not re-ordered
+	SortedKeys(c Comparator[K]) GroupedStream[K, T]
+}
+
+// GroupBy creates a new GroupedStream from the elements of the stream - grouped by the key provided by the key function
+//
+// groups are in order of first encounter of each key, and elements within each group are in stream order
+//
+// keys that are not equal to themselves (i.e. floating point NaN) are all grouped into a single group
+//
+// if the provided key function is nil, the grouped stream is empty
+func GroupBy[T any, K comparable](s Stream[T], key func(v T) K) GroupedStream[K, T] {
+	r := newGroupedStream[K, T]()
+	if key != nil {
+		for _, v := range elementsOf(s) {
+			r.add(key(v), v)
+		}
+	}
+	return r
+}
+
+// ReduceGroups creates a new stream of pairs of each key and the reduction of its group using the provided Reducer
+//
+// if the provided reducer is nil, the result is always empty
+func ReduceGroups[K comparable, T any, R any](g GroupedStream[K, T], r Reducer[T, R]) Stream[Pair[K, R]] {
+	if r == nil {
+		return &stream[Pair[K, R]]{
+			elements: make([]Pair[K, R], 0),
+		}
+	}
+	return MapGroups(g, func(k K, group Stream[T]) Pair[K, R] {
+		return Pair[K, R]{First: k, Second: r.Reduce(group)}
+	})
+}
+
+// MapGroups creates a new stream of the results of the provided function applied to each key and its group
+//
+// if the provided function is nil, the result is always empty
+func MapGroups[K comparable, T any, R any](g GroupedStream[K, T], f func(k K, group Stream[T]) R) Stream[R] {
+	r := &stream[R]{
+		elements: make([]R, 0),
+	}
+	if f != nil && g != nil {
+		for _, pr := range g.Groups().AsSlice() {
+			r.elements = append(r.elements, f(pr.First, pr.Second))
+		}
+	}
+	return r
+}
+
+type groupedStream[K comparable, T any] struct {
+	*keyedGroups[K, T]
+}
+
+func newGroupedStream[K comparable, T any]() *groupedStream[K, T] {
+	return &groupedStream[K, T]{
+		keyedGroups: newKeyedGroups[K, T](),
+	}
+}
+
+// keyedGroups groups elements by key - in first-encounter order of keys (as used by GroupBy and Query.GroupBy)
+//
+// keys that are not hashable (e.g. slices or maps held in an interface) are compared using reflect.DeepEqual, and keys that
+// are not equal to themselves (e.g. NaN) are all taken as the same group
+type keyedGroups[K any, T any] struct {
+	keys       []K
+	groups     [][]T       // the groups (in the same order as the keys)
+	index      map[any]int // index of the group for each hashable key
+	nan        int         // index of the group for keys that are not equal to themselves - or -1 if none
+	unhashable []int       // indexes of the groups for unhashable keys
+}
+
+func newKeyedGroups[K any, T any]() *keyedGroups[K, T] {
+	return &keyedGroups[K, T]{
+		keys:   make([]K, 0),
+		groups: make([][]T, 0),
+		index:  map[any]int{},
+		nan:    -1,
+	}
+}
+
+// indexOf returns the index of the group for the key
+func (g *keyedGroups[K, T]) indexOf(k K) (int, bool) {
+	ak := any(k)
+	if !isHashable(ak) {
+		for _, i := range g.unhashable {
+			if reflect.DeepEqual(any(g.keys[i]), ak) {
+				return i, true
+			}
+		}
+		return -1, false
+	} else if ak != ak {
+		return g.nan, g.nan != -1
+	}
+	i, ok := g.index[ak]
+	return i, ok
+}
+
+func (g *keyedGroups[K, T]) add(k K, vs ...T) {
+	i, ok := g.indexOf(k)
+	if !ok {
+		i = len(g.keys)
+		g.keys = append(g.keys, k)
+		g.groups = append(g.groups, nil)
+		if ak := any(k); !isHashable(ak) {
+			g.unhashable = append(g.unhashable, i)
+		} else if ak != ak {
+			g.nan = i
+		} else {
+			g.index[ak] = i
+		}
+	}
+	g.groups[i] = append(g.groups[i], vs...)
+}
+
+func (g *groupedStream[K, T]) Count() Stream[Pair[K, int]] {
+	r := make([]Pair[K, int], len(g.keys))
+	for i, k := range g.keys {
+		r[i] = Pair[K, int]{First: k, Second: len(g.groups[i])}
+	}
+	return &stream[Pair[K, int]]{
+		elements: r,
+	}
+}
+
+func (g *groupedStream[K, T]) Flatten() Stream[T] {
+	r := make([]T, 0)
+	for _, group := range g.groups {
+		r = append(r, group...)
+	}
+	return &stream[T]{
+		elements: r,
+	}
+}
+
+func (g *groupedStream[K, T]) Get(k K) Stream[T] {
+	if i, ok := g.indexOf(k); ok {
+		return g.group(i)
+	}
+	return &stream[T]{
+		elements: make([]T, 0),
+	}
+}
+
+func (g *groupedStream[K, T]) group(i int) Stream[T] {
+	return &stream[T]{
+		elements: append(make([]T, 0, len(g.groups[i])), g.groups[i]...),
+	}
+}
+
+func (g *groupedStream[K, T]) Groups() Stream[Pair[K, Stream[T]]] {
+	r := make([]Pair[K, Stream[T]], len(g.keys))
+	for i, k := range g.keys {
+		r[i] = Pair[K, Stream[T]]{First: k, Second: g.group(i)}
+	}
+	return &stream[Pair[K, Stream[T]]]{
+		elements: r,
+	}
+}
+
+func (g *groupedStream[K, T]) Has(k K) bool {
+	_, ok := g.indexOf(k)
+	return ok
+}
+
+func (g *groupedStream[K, T]) Keys() Stream[K] {
+	return &stream[K]{
+		elements: append(make([]K, 0, len(g.keys)), g.keys...),
+	}
+}
+
+func (g *groupedStream[K, T]) Len() int {
+	return len(g.keys)
+}
+
+func (g *groupedStream[K, T]) SortedKeys(c Comparator[K]) GroupedStream[K, T] {
+	order := make([]int, len(g.keys))
+	for i := range order {
+		order[i] = i
+	}
+	if c != nil {
+		sort.SliceStable(order, func(i, j int) bool {
+			return c.Less(g.keys[order[i]], g.keys[order[j]])
+		})
+	}
+	r := newGroupedStream[K, T]()
+	for _, i := range order {
+		r.add(g.keys[i], g.groups[i]...)
+	}
+	return r
+}
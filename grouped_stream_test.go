@@ -0,0 +1,133 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"math"
+	"strings"
+	"testing"
+)
+
+var groupedWords = Of("apple", "banana", "avocado", "cherry", "blueberry", "apricot", "coconut")
+
+func firstLetter(v string) string {
+	return v[:1]
+}
+
+func TestGroupBy(t *testing.T) {
+	g := GroupBy(groupedWords, firstLetter)
+	require.Equal(t, 3, g.Len())
+	require.Equal(t, []string{"a", "b", "c"}, g.Keys().AsSlice())
+	require.Equal(t, []string{"apple", "avocado", "apricot"}, g.Get("a").AsSlice())
+	require.Equal(t, []string{"banana", "blueberry"}, g.Get("b").AsSlice())
+	require.Equal(t, 0, g.Get("z").Len())
+	require.True(t, g.Has("c"))
+	require.False(t, g.Has("z"))
+
+	g = GroupBy(Of("b", "a", "c", "a", "b"), func(v string) string {
+		return v
+	})
+	require.Equal(t, []string{"b", "a", "c"}, g.Keys().AsSlice())
+
+	g = GroupBy[string, string](groupedWords, nil)
+	require.Equal(t, 0, g.Len())
+	require.Equal(t, 0, g.Flatten().Len())
+	g = GroupBy(nil, firstLetter)
+	require.Equal(t, 0, g.Len())
+}
+
+func TestGroupedStream_Count(t *testing.T) {
+	g := GroupBy(groupedWords, firstLetter)
+	require.Equal(t, []Pair[string, int]{{"a", 3}, {"b", 2}, {"c", 2}}, g.Count().AsSlice())
+}
+
+func TestGroupedStream_Flatten(t *testing.T) {
+	g := GroupBy(groupedWords, firstLetter)
+	require.Equal(t, []string{"apple", "avocado", "apricot", "banana", "blueberry", "cherry", "coconut"}, g.Flatten().AsSlice())
+	require.Equal(t, []string{"cherry", "coconut", "banana", "blueberry", "apple", "avocado", "apricot"},
+		g.SortedKeys(StringComparator.Reversed()).Flatten().AsSlice())
+}
+
+func TestGroupedStream_Groups(t *testing.T) {
+	g := GroupBy(groupedWords, firstLetter)
+	groups := g.Groups().AsSlice()
+	require.Equal(t, 3, len(groups))
+	require.Equal(t, "b", groups[1].First)
+	require.Equal(t, []string{"banana", "blueberry"}, groups[1].Second.AsSlice())
+}
+
+func TestGroupedStream_SortedKeys(t *testing.T) {
+	g := GroupBy(Of(3, 1, 4, 1, 5, 9, 2, 6), func(v int) int {
+		return v % 3
+	})
+	require.Equal(t, []int{0, 1, 2}, g.Keys().AsSlice())
+	sg := g.SortedKeys(IntComparator.Reversed())
+	require.Equal(t, []int{2, 1, 0}, sg.Keys().AsSlice())
+	require.Equal(t, []int{5, 2}, sg.Get(2).AsSlice())
+	// original is unaffected...
+	require.Equal(t, []int{0, 1, 2}, g.Keys().AsSlice())
+	require.Equal(t, []int{0, 1, 2}, g.SortedKeys(nil).Keys().AsSlice())
+}
+
+func TestReduceGroups(t *testing.T) {
+	g := GroupBy(groupedWords, firstLetter)
+	r := NewReducer(NewAccumulator(func(v string, r int) int {
+		return r + len(v)
+	}))
+	require.Equal(t, []Pair[string, int]{{"a", 19}, {"b", 15}, {"c", 13}}, ReduceGroups(g, r).AsSlice())
+	require.Equal(t, 0, ReduceGroups[string, string, int](g, nil).Len())
+}
+
+func TestMapGroups(t *testing.T) {
+	g := GroupBy(groupedWords, firstLetter)
+	s := MapGroups(g, func(k string, group Stream[string]) string {
+		return k + ":" + strings.Join(group.AsSlice(), ",")
+	})
+	require.Equal(t, []string{"a:apple,avocado,apricot", "b:banana,blueberry", "c:cherry,coconut"}, s.AsSlice())
+	require.Equal(t, 0, MapGroups[string, string, string](g, nil).Len())
+	require.Equal(t, 0, MapGroups(nil, func(k string, group Stream[string]) string {
+		return k
+	}).Len())
+}
+
+func TestGroupBy_NaNKeys(t *testing.T) {
+	nan := math.NaN()
+	g := GroupBy(Of(1.0, nan, 2.0, nan, 1.0), func(v float64) float64 {
+		return v
+	})
+	require.Equal(t, 3, g.Len())
+	require.Equal(t, 5, g.Flatten().Len())
+	counts := g.Count().AsSlice()
+	require.Equal(t, 1.0, counts[0].First)
+	require.Equal(t, 2, counts[0].Second)
+	require.True(t, math.IsNaN(counts[1].First))
+	require.Equal(t, 2, counts[1].Second)
+	require.Equal(t, 2.0, counts[2].First)
+	require.Equal(t, 1, counts[2].Second)
+	require.True(t, g.Has(nan))
+	require.Equal(t, 2, g.Get(nan).Len())
+	require.Equal(t, 2, g.Get(1.0).Len())
+
+	// NaN ordered first...
+	sg := g.SortedKeys(NewComparator(func(v1, v2 float64) int {
+		n1, n2 := math.IsNaN(v1), math.IsNaN(v2)
+		switch {
+		case n1 && n2:
+			return 0
+		case n1:
+			return -1
+		case n2:
+			return 1
+		}
+		return IntComparator.Compare(int(v1), int(v2))
+	}))
+	require.Equal(t, 5, sg.Flatten().Len())
+	require.Equal(t, 2, sg.Get(nan).Len())
+	keys := sg.Keys().AsSlice()
+	require.Equal(t, 3, len(keys))
+	require.True(t, math.IsNaN(keys[0]))
+	require.Equal(t, []float64{1.0, 2.0}, keys[1:])
+
+	require.False(t, GroupBy(Of(1.0), func(v float64) float64 {
+		return v
+	}).Has(nan))
+}
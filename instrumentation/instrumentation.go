@@ -0,0 +1,155 @@
+// Package instrumentation - optional tracing instrumentation for streams
+/*
+Wraps streams.Stream, streams.Mapper and streams.Reducer so that each terminal operation creates a span (with element counts,
+error status and elapsed time) using a small Tracer interface - which can be backed by OpenTelemetry, or by the provided
+no-op (NoopTracer) or in-memory (Recorder) tracers
+*/
+package instrumentation
+
+import (
+	"context"
+	"github.com/go-andiamo/streams"
+	"time"
+)
+
+const (
+	// AttributeOperation is the span attribute key for the name of the stream operation
+	AttributeOperation = "streams.operation"
+	// AttributeInputCount is the span attribute key for the number of input elements
+	AttributeInputCount = "streams.input_count"
+	// AttributeOutputCount is the span attribute key for the number of output elements (only for operations that produce elements)
+	AttributeOutputCount = "streams.output_count"
+	// AttributeElapsed is the span attribute key for the elapsed time of the operation
+	AttributeElapsed = "streams.elapsed"
+	// SpanNamePrefix is the prefix of all span names
+	SpanNamePrefix = "streams."
+)
+
+// Tracer is the interface used to create spans
+//
+// an OpenTelemetry backed implementation would typically start a span with the start timestamp, for example:
+//  func (t *otelTracer) Start(ctx context.Context, name string, start time.Time) Span {
+//      _, span := t.tracer.Start(ctx, name, trace.WithTimestamp(start))
+//      return &otelSpan{span: span}
+//  }
+type Tracer interface {
+	// Start starts a new span with the specified name and start time
+	Start(ctx context.Context, name string, start time.Time) Span
+}
+
+// Span is the interface for a span created by a Tracer
+type Span interface {
+	// SetAttribute sets an attribute on the span
+	SetAttribute(key string, value any)
+	// RecordError records an error on the span (and sets the span status to error)
+	RecordError(err error)
+	// End ends the span at the specified end time
+	End(end time.Time)
+}
+
+// WrapStream creates a new stream around the supplied stream where each terminal operation (on the stream and on
+// streams produced by its operations) creates a span using the supplied tracer
+//
+// spans are started using the supplied context (which may be nil)
+//
+// if the supplied tracer is nil, the supplied stream is returned
+func WrapStream[T any](ctx context.Context, s streams.Stream[T], tracer Tracer) streams.Stream[T] {
+	if tracer == nil {
+		return s
+	}
+	return streams.Traced(s, streams.NewTracer(func(event streams.TraceEvent) {
+		if event.Terminal {
+			end := time.Now()
+			attrs := map[string]any{
+				AttributeOperation: event.Operation,
+			}
+			if event.InputCount >= 0 {
+				attrs[AttributeInputCount] = event.InputCount
+			}
+			span(ctx, tracer, event.Operation, end.Add(-event.Duration), end, attrs, event.Err)
+		}
+	}))
+}
+
+// WrapMapper creates a new Mapper around the supplied mapper where each call to Map creates a span using the supplied tracer
+//
+// spans are started using the supplied context (which may be nil)
+//
+// if the supplied tracer is nil, the supplied mapper is returned
+func WrapMapper[T any, R any](ctx context.Context, m streams.Mapper[T, R], tracer Tracer) streams.Mapper[T, R] {
+	if tracer == nil {
+		return m
+	}
+	return &mapper[T, R]{
+		ctx:    ctx,
+		inner:  m,
+		tracer: tracer,
+	}
+}
+
+type mapper[T any, R any] struct {
+	ctx    context.Context
+	inner  streams.Mapper[T, R]
+	tracer Tracer
+}
+
+func (m *mapper[T, R]) Map(in streams.Stream[T]) (streams.Stream[R], error) {
+	start := time.Now()
+	r, err := m.inner.Map(in)
+	attrs := map[string]any{
+		AttributeOperation:  "Mapper.Map",
+		AttributeInputCount: in.Len(),
+	}
+	if r != nil {
+		attrs[AttributeOutputCount] = r.Len()
+	}
+	span(m.ctx, m.tracer, "Mapper.Map", start, time.Now(), attrs, err)
+	return r, err
+}
+
+// WrapReducer creates a new Reducer around the supplied reducer where each call to Reduce creates a span using the supplied tracer
+//
+// spans are started using the supplied context (which may be nil)
+//
+// if the supplied tracer is nil, the supplied reducer is returned
+func WrapReducer[T any, R any](ctx context.Context, r streams.Reducer[T, R], tracer Tracer) streams.Reducer[T, R] {
+	if tracer == nil {
+		return r
+	}
+	return &reducer[T, R]{
+		ctx:    ctx,
+		inner:  r,
+		tracer: tracer,
+	}
+}
+
+type reducer[T any, R any] struct {
+	ctx    context.Context
+	inner  streams.Reducer[T, R]
+	tracer Tracer
+}
+
+func (r *reducer[T, R]) Reduce(s streams.Stream[T]) R {
+	start := time.Now()
+	result := r.inner.Reduce(s)
+	span(r.ctx, r.tracer, "Reducer.Reduce", start, time.Now(), map[string]any{
+		AttributeOperation:  "Reducer.Reduce",
+		AttributeInputCount: s.Len(),
+	}, nil)
+	return result
+}
+
+func span(ctx context.Context, tracer Tracer, operation string, start time.Time, end time.Time, attrs map[string]any, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	sp := tracer.Start(ctx, SpanNamePrefix+operation, start)
+	for k, v := range attrs {
+		sp.SetAttribute(k, v)
+	}
+	sp.SetAttribute(AttributeElapsed, end.Sub(start))
+	if err != nil {
+		sp.RecordError(err)
+	}
+	sp.End(end)
+}
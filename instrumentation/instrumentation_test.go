@@ -0,0 +1,90 @@
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"github.com/go-andiamo/streams"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+func TestWrapStream(t *testing.T) {
+	rec := NewRecorder()
+	s := WrapStream(context.Background(), streams.Of(5, 1, 4, 2, 3), rec)
+	isEven := streams.NewPredicate(func(v int) bool {
+		return v%2 == 0
+	})
+	c := s.Filter(isEven).Sorted(streams.IntComparator).Count(nil)
+	require.Equal(t, 2, c)
+	spans := rec.Spans()
+	require.Equal(t, 1, len(spans))
+	require.Equal(t, "streams.Count", spans[0].Name)
+	require.True(t, spans[0].Ended)
+	require.Equal(t, "Count", spans[0].Attributes[AttributeOperation])
+	require.Equal(t, 2, spans[0].Attributes[AttributeInputCount])
+	require.Equal(t, spans[0].Duration(), spans[0].Attributes[AttributeElapsed])
+	require.False(t, spans[0].EndTime.Before(spans[0].StartTime))
+	require.NoError(t, spans[0].Err)
+}
+
+func TestWrapStream_Error(t *testing.T) {
+	rec := NewRecorder()
+	s := WrapStream(nil, streams.Of(1, 2, 3), rec)
+	err := s.ForEach(streams.NewConsumer(func(v int) error {
+		return errors.New("fooey")
+	}))
+	require.Error(t, err)
+	spans := rec.Spans()
+	require.Equal(t, 1, len(spans))
+	require.Equal(t, "streams.ForEach", spans[0].Name)
+	require.Equal(t, err, spans[0].Err)
+}
+
+func TestWrapStream_NilTracer(t *testing.T) {
+	s := streams.Of(1, 2, 3)
+	require.Equal(t, s, WrapStream(context.Background(), s, nil))
+}
+
+func TestWrapMapper(t *testing.T) {
+	rec := NewRecorder()
+	m := WrapMapper(context.Background(), streams.NewMapper(streams.NewConverter(func(v string) (int, error) {
+		return strconv.Atoi(v)
+	})), rec)
+	out, err := m.Map(streams.Of("1", "2", "3"))
+	require.NoError(t, err)
+	require.Equal(t, 3, out.Len())
+	_, err = m.Map(streams.Of("1", "x"))
+	require.Error(t, err)
+
+	spans := rec.Spans()
+	require.Equal(t, 2, len(spans))
+	require.Equal(t, "streams.Mapper.Map", spans[0].Name)
+	require.Equal(t, 3, spans[0].Attributes[AttributeInputCount])
+	require.Equal(t, 3, spans[0].Attributes[AttributeOutputCount])
+	require.NoError(t, spans[0].Err)
+	require.Equal(t, 2, spans[1].Attributes[AttributeInputCount])
+	_, ok := spans[1].Attributes[AttributeOutputCount]
+	require.False(t, ok)
+	require.Error(t, spans[1].Err)
+
+	inner := streams.NewMapper(streams.NewConverter(func(v string) (int, error) {
+		return 0, nil
+	}))
+	_, ok = WrapMapper(context.Background(), inner, nil).(*mapper[string, int])
+	require.False(t, ok)
+}
+
+func TestWrapReducer(t *testing.T) {
+	rec := NewRecorder()
+	r := WrapReducer(nil, streams.NewReducer(streams.NewAccumulator(func(v int, r int) int {
+		return r + v
+	})), rec)
+	require.Equal(t, 6, r.Reduce(streams.Of(1, 2, 3)))
+	spans := rec.Spans()
+	require.Equal(t, 1, len(spans))
+	require.Equal(t, "streams.Reducer.Reduce", spans[0].Name)
+	require.Equal(t, 3, spans[0].Attributes[AttributeInputCount])
+
+	require.Nil(t, WrapReducer[int, int](nil, nil, nil))
+}
@@ -0,0 +1,114 @@
+package instrumentation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NoopTracer is a Tracer that does nothing
+var NoopTracer Tracer = noopTracer{}
+
+type noopTracer struct{}
+
+func (n noopTracer) Start(ctx context.Context, name string, start time.Time) Span {
+	return noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (n noopSpan) SetAttribute(key string, value any) {}
+
+func (n noopSpan) RecordError(err error) {}
+
+func (n noopSpan) End(end time.Time) {}
+
+// Recorder is an in-memory Tracer that records spans - intended for use in tests
+type Recorder struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+// NewRecorder creates a new in-memory Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		spans: make([]*recordingSpan, 0),
+	}
+}
+
+// Start starts a new span with the specified name and start time
+func (r *Recorder) Start(ctx context.Context, name string, start time.Time) Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sp := &recordingSpan{
+		recorder: r,
+		span: RecordedSpan{
+			Name:       name,
+			StartTime:  start,
+			Attributes: map[string]any{},
+		},
+	}
+	r.spans = append(r.spans, sp)
+	return sp
+}
+
+// Spans returns a copy of the spans recorded so far
+func (r *Recorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]RecordedSpan, len(r.spans))
+	for i, sp := range r.spans {
+		result[i] = sp.span
+		result[i].Attributes = make(map[string]any, len(sp.span.Attributes))
+		for k, v := range sp.span.Attributes {
+			result[i].Attributes[k] = v
+		}
+	}
+	return result
+}
+
+// Reset clears the spans recorded so far
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = make([]*recordingSpan, 0)
+}
+
+// RecordedSpan is a span recorded by a Recorder
+type RecordedSpan struct {
+	Name       string
+	StartTime  time.Time
+	EndTime    time.Time
+	Ended      bool
+	Attributes map[string]any
+	Err        error
+}
+
+// Duration returns the duration of the span
+func (s RecordedSpan) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+type recordingSpan struct {
+	recorder *Recorder
+	span     RecordedSpan
+}
+
+func (s *recordingSpan) SetAttribute(key string, value any) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.span.Attributes[key] = value
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.span.Err = err
+}
+
+func (s *recordingSpan) End(end time.Time) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.span.EndTime = end
+	s.span.Ended = true
+}
@@ -0,0 +1,42 @@
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"github.com/go-andiamo/streams"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNoopTracer(t *testing.T) {
+	s := WrapStream(context.Background(), streams.Of(1, 2, 3), NoopTracer)
+	require.Equal(t, 3, s.Count(nil))
+	sp := NoopTracer.Start(context.Background(), "test", time.Now())
+	sp.SetAttribute("a", 1)
+	sp.RecordError(errors.New("fooey"))
+	sp.End(time.Now())
+}
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder()
+	start := time.Now()
+	sp := rec.Start(context.Background(), "test", start)
+	sp.SetAttribute("a", 1)
+	spans := rec.Spans()
+	require.Equal(t, 1, len(spans))
+	require.False(t, spans[0].Ended)
+	// copies are returned...
+	spans[0].Attributes["b"] = 2
+	sp.RecordError(errors.New("fooey"))
+	sp.End(start.Add(time.Second))
+	spans = rec.Spans()
+	require.Equal(t, "test", spans[0].Name)
+	require.True(t, spans[0].Ended)
+	require.Equal(t, time.Second, spans[0].Duration())
+	require.Equal(t, map[string]any{"a": 1}, spans[0].Attributes)
+	require.Error(t, spans[0].Err)
+
+	rec.Reset()
+	require.Equal(t, 0, len(rec.Spans()))
+}
@@ -0,0 +1,411 @@
+package streams
+
+import (
+	"github.com/go-andiamo/gopt"
+)
+
+// lazyStream is a Stream implementation around a generator (pull) function
+//
+// elements are only pulled from the generator as they are needed - operations that can short-circuit (e.g. FirstMatch, AnyMatch, Limit)
+// only pull as many elements as they need, whereas operations that need all elements (e.g. Len, Sorted, Reverse) pull
+// all remaining elements
+//
+// pulled elements are buffered, so the stream can be used repeatedly
+type lazyStream[T any] struct {
+	next     func() (T, bool)
+	buffer   []T
+	finished bool
+}
+
+func newLazyStream[T any](next func() (T, bool)) *lazyStream[T] {
+	return &lazyStream[T]{
+		next: next,
+	}
+}
+
+// pull ensures that the buffer contains at least n elements (or that the generator is exhausted)
+func (s *lazyStream[T]) pull(n int) bool {
+	for !s.finished && len(s.buffer) < n {
+		if v, ok := s.next(); ok {
+			s.buffer = append(s.buffer, v)
+		} else {
+			s.finished = true
+			s.next = nil
+		}
+	}
+	return len(s.buffer) >= n
+}
+
+func (s *lazyStream[T]) all() []T {
+	for !s.finished {
+		s.pull(len(s.buffer) + 1)
+	}
+	return s.buffer
+}
+
+func (s *lazyStream[T]) materialized() *stream[T] {
+	return &stream[T]{
+		elements: s.all(),
+	}
+}
+
+// iterate returns a pull function over this stream - that reads from the buffer, pulling from the generator as needed
+func (s *lazyStream[T]) iterate() func() (T, bool) {
+	curr := 0
+	return func() (T, bool) {
+		var r T
+		if s.pull(curr + 1) {
+			r = s.buffer[curr]
+			curr++
+			return r, true
+		}
+		return r, false
+	}
+}
+
+// AllMatch returns whether all elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *lazyStream[T]) AllMatch(p Predicate[T]) bool {
+	if p == nil || !s.pull(1) {
+		return false
+	}
+	next := s.iterate()
+	for v, ok := next(); ok; v, ok = next() {
+		if !p.Test(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyMatch returns whether any elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *lazyStream[T]) AnyMatch(p Predicate[T]) bool {
+	if p != nil {
+		next := s.iterate()
+		for v, ok := next(); ok; v, ok = next() {
+			if p.Test(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Append creates a new stream with all the elements of this stream followed by the specified elements
+func (s *lazyStream[T]) Append(items ...T) Stream[T] {
+	add := make([]T, len(items))
+	copy(add, items)
+	return s.Concat(&stream[T]{elements: add})
+}
+
+// AsSlice returns the underlying slice
+//
+// Note: all elements are pulled
+func (s *lazyStream[T]) AsSlice() []T {
+	return s.all()
+}
+
+// Concat creates a new stream with all the elements of this stream followed by all the elements of the added stream
+func (s *lazyStream[T]) Concat(add Stream[T]) Stream[T] {
+	next := s.iterate()
+	var addNext func() (T, bool)
+	return newLazyStream[T](func() (T, bool) {
+		if addNext == nil {
+			if v, ok := next(); ok {
+				return v, true
+			}
+			addNext = add.Iterator()
+		}
+		return addNext()
+	})
+}
+
+// Count returns the count of elements that match the provided predicate
+//
+// If the predicate is nil, returns the count of all elements
+func (s *lazyStream[T]) Count(p Predicate[T]) int {
+	return s.materialized().Count(p)
+}
+
+// Difference creates a new stream that is the set difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *lazyStream[T]) Difference(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.materialized().Difference(other, c)
+}
+
+// Distinct creates a new stream of distinct elements in this stream
+//
+// elements whose values are not hashable (e.g. slices, maps or funcs) are compared using reflect.DeepEqual
+func (s *lazyStream[T]) Distinct() Stream[T] {
+	return s.materialized().Distinct()
+}
+
+// Filter creates a new stream of elements in this stream that match the provided predicate
+//
+// if the provided predicate is nil, all elements in this stream are returned
+func (s *lazyStream[T]) Filter(p Predicate[T]) Stream[T] {
+	return newLazyStream[T](s.Iterator(p))
+}
+
+// FirstMatch returns an optional of the first element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the first element in this stream is returned
+func (s *lazyStream[T]) FirstMatch(p Predicate[T]) *gopt.Optional[T] {
+	if v, ok := s.Iterator(p)(); ok {
+		return gopt.Of[T](v)
+	}
+	return gopt.Empty[T]()
+}
+
+// ForEach performs an action on each element of this stream
+//
+// the action to be performed is defined by the provided consumer
+//
+// if the provided consumer is nil, nothing is performed
+func (s *lazyStream[T]) ForEach(c Consumer[T]) error {
+	if c != nil {
+		next := s.iterate()
+		for v, ok := next(); ok; v, ok = next() {
+			if err := c.Accept(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Has returns whether this stream contains an element that is equal to the element value provided
+//
+// equality is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false
+func (s *lazyStream[T]) Has(v T, c Comparator[T]) bool {
+	if c != nil {
+		next := s.iterate()
+		for v2, ok := next(); ok; v2, ok = next() {
+			if c.Compare(v, v2) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Intersection creates a new stream that is the set intersection of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *lazyStream[T]) Intersection(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.materialized().Intersection(other, c)
+}
+
+// Iterator returns an iterator (pull) function
+//
+// the iterator function can be used in for loops, for example
+//  next := strm.Iterator()
+//  for v, ok := next(); ok; v, ok = next() {
+//      fmt.Println(v)
+//  }
+//
+// Iterator can also optionally accept varargs of Predicate - which, if specified, are logically OR-ed on each pull to ensure
+// that pulled elements match
+func (s *lazyStream[T]) Iterator(ps ...Predicate[T]) func() (T, bool) {
+	next := s.iterate()
+	if p := joinPredicates[T](ps...); p != nil {
+		return func() (T, bool) {
+			for v, ok := next(); ok; v, ok = next() {
+				if p.Test(v) {
+					return v, true
+				}
+			}
+			var r T
+			return r, false
+		}
+	}
+	return next
+}
+
+// LastMatch returns an optional of the last element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the last element in this stream is returned
+func (s *lazyStream[T]) LastMatch(p Predicate[T]) *gopt.Optional[T] {
+	return s.materialized().LastMatch(p)
+}
+
+// Len returns the length (number of elements) of this stream
+//
+// Note: all elements are pulled
+func (s *lazyStream[T]) Len() int {
+	return len(s.all())
+}
+
+// Limit creates a new stream whose number of elements is limited to the value provided
+//
+// if the maximum size is greater than the length of this stream, all elements are returned
+func (s *lazyStream[T]) Limit(maxSize int) Stream[T] {
+	max := absZero(maxSize)
+	next := s.iterate()
+	count := 0
+	return newLazyStream[T](func() (T, bool) {
+		if count < max {
+			count++
+			return next()
+		}
+		var r T
+		return r, false
+	})
+}
+
+// Max returns the maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *lazyStream[T]) Max(c Comparator[T]) *gopt.Optional[T] {
+	return s.materialized().Max(c)
+}
+
+// Min returns the minimum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *lazyStream[T]) Min(c Comparator[T]) *gopt.Optional[T] {
+	return s.materialized().Min(c)
+}
+
+// MinMax returns the minimum and maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned for both
+func (s *lazyStream[T]) MinMax(c Comparator[T]) (*gopt.Optional[T], *gopt.Optional[T]) {
+	return s.materialized().MinMax(c)
+}
+
+// NoneMatch returns whether none of the elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns true
+func (s *lazyStream[T]) NoneMatch(p Predicate[T]) bool {
+	return p == nil || !s.AnyMatch(p)
+}
+
+// NthMatch returns an optional of the nth matching element (1 based) according to the provided predicate
+//
+// if the nth argument is negative, the nth is taken as relative to the last
+//
+// if the provided predicate is nil, any element is taken as matching
+//
+// if no elements match in the specified position, an empty (not present) optional is returned
+func (s *lazyStream[T]) NthMatch(p Predicate[T], nth int) *gopt.Optional[T] {
+	if nth < 0 {
+		return s.materialized().NthMatch(p, nth)
+	} else if nth > 0 {
+		next := s.Iterator(p)
+		for v, ok := next(); ok; v, ok = next() {
+			if nth--; nth == 0 {
+				return gopt.Of[T](v)
+			}
+		}
+	}
+	return gopt.Empty[T]()
+}
+
+// Page returns the nth page (1 based) of elements of this stream, where each page has the specified size - along with
+// page metadata (total elements, total pages and whether there are next/previous pages)
+//
+// if the page number is out of range or the size is less than 1, the page elements are empty
+func (s *lazyStream[T]) Page(n int, size int) Page[T] {
+	return newPage(s.all(), n, size)
+}
+
+// Paginate returns an iterator over the pages of this stream, where each page has the specified page size
+//
+// if the page size is less than 1, there are no pages
+func (s *lazyStream[T]) Paginate(pageSize int) Pages[T] {
+	return newPages(s.all(), pageSize)
+}
+
+// Peek creates a new stream with the same elements as this stream, additionally performing the provided action on each element
+//
+// Peek is intended for debugging - for example, to see elements as they flow through a chain of operations
+//
+// any error returned by the consumer is ignored, and if the provided consumer is nil, nothing is performed
+//
+// the action is performed lazily - i.e. as each element is pulled
+func (s *lazyStream[T]) Peek(c Consumer[T]) Stream[T] {
+	next := s.iterate()
+	return newLazyStream[T](func() (T, bool) {
+		v, ok := next()
+		if ok && c != nil {
+			_ = c.Accept(v)
+		}
+		return v, ok
+	})
+}
+
+// Reverse creates a new stream composed of elements from this stream but in reverse order
+func (s *lazyStream[T]) Reverse() Stream[T] {
+	return s.materialized().Reverse()
+}
+
+// Skip creates a new stream consisting of this stream after discarding the first n elements
+//
+// if the specified n to skip is equal to or greater than the number of elements in this stream,
+// an empty stream is returned
+func (s *lazyStream[T]) Skip(n int) Stream[T] {
+	skip := absZero(n)
+	next := s.iterate()
+	return newLazyStream[T](func() (T, bool) {
+		for ; skip > 0; skip-- {
+			if _, ok := next(); !ok {
+				break
+			}
+		}
+		return next()
+	})
+}
+
+// Slice creates a new stream composed of elements from this stream starting at the specified start and including
+// the specified count (or to the end)
+//
+// the start is zero based (and less than zero is ignored)
+//
+// if the specified count is negative, items are selected from the start and then backwards by the count
+func (s *lazyStream[T]) Slice(start int, count int) Stream[T] {
+	if count >= 0 {
+		return s.Skip(start).Limit(count)
+	}
+	return s.materialized().Slice(start, count)
+}
+
+// Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
+//
+// if the provided comparator is nil, the elements are not sorted
+func (s *lazyStream[T]) Sorted(c Comparator[T]) SortedStream[T] {
+	return s.materialized().Sorted(c)
+}
+
+// SymmetricDifference creates a new stream that is the set symmetric difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *lazyStream[T]) SymmetricDifference(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.materialized().SymmetricDifference(other, c)
+}
+
+// Union creates a new stream that is the set union of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *lazyStream[T]) Union(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.materialized().Union(other, c)
+}
+
+// Unique creates a new stream of unique elements in this stream
+//
+// uniqueness is determined using the provided comparator
+//
+// if provided comparator is nil, Distinct is used as the result
+func (s *lazyStream[T]) Unique(c Comparator[T]) Stream[T] {
+	return s.materialized().Unique(c)
+}
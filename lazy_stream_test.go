@@ -0,0 +1,149 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testLazyStream[T any](values ...T) (*lazyStream[T], *int) {
+	pulled := 0
+	next := SliceIterator(values)
+	return newLazyStream[T](func() (T, bool) {
+		v, ok := next()
+		if ok {
+			pulled++
+		}
+		return v, ok
+	}), &pulled
+}
+
+func TestLazyStream_Repeatable(t *testing.T) {
+	s, pulled := testLazyStream("a", "b", "c")
+	require.Equal(t, 0, *pulled)
+	require.Equal(t, 3, s.Len())
+	require.Equal(t, 3, s.Len())
+	require.Equal(t, []string{"a", "b", "c"}, s.AsSlice())
+	require.Equal(t, 3, *pulled)
+}
+
+func TestLazyStream_ShortCircuits(t *testing.T) {
+	s, pulled := testLazyStream(1, 2, 3, 4, 5)
+	isEven := NewPredicate(func(v int) bool {
+		return v%2 == 0
+	})
+	o := s.FirstMatch(isEven)
+	require.True(t, o.IsPresent())
+	require.Equal(t, 2, o.Default(0))
+	require.Equal(t, 2, *pulled)
+	require.True(t, s.AnyMatch(isEven))
+	require.False(t, s.NoneMatch(isEven))
+	require.False(t, s.AllMatch(isEven))
+	require.True(t, s.Has(1, IntComparator))
+	require.Equal(t, 2, *pulled)
+	require.Equal(t, 4, s.NthMatch(isEven, 2).Default(0))
+	require.Equal(t, 4, *pulled)
+	require.False(t, s.NthMatch(isEven, 3).IsPresent())
+	require.Equal(t, 5, *pulled)
+	require.Equal(t, 4, s.NthMatch(isEven, -1).Default(0))
+	require.False(t, s.NthMatch(isEven, 0).IsPresent())
+	require.False(t, s.Has(6, nil))
+	require.False(t, s.AllMatch(nil))
+	require.False(t, s.AnyMatch(nil))
+	require.True(t, s.NoneMatch(nil))
+
+	e, _ := testLazyStream[int]()
+	require.False(t, e.AllMatch(isEven))
+	require.False(t, e.FirstMatch(nil).IsPresent())
+}
+
+func TestLazyStream_LazyOperations(t *testing.T) {
+	s, pulled := testLazyStream(1, 2, 3, 4, 5, 6, 7, 8)
+	isEven := NewPredicate(func(v int) bool {
+		return v%2 == 0
+	})
+	r := s.Filter(isEven).Skip(1).Limit(2)
+	require.Equal(t, 0, *pulled)
+	require.Equal(t, []int{4, 6}, r.AsSlice())
+	require.Equal(t, 6, *pulled)
+	require.Equal(t, []int{3, 4}, s.Slice(2, 2).AsSlice())
+	require.Equal(t, 6, *pulled)
+	require.Equal(t, []int{1, 2}, s.Slice(2, -2).AsSlice())
+	require.Equal(t, 0, s.Skip(10).Len())
+	require.Equal(t, 0, s.Limit(-1).Len())
+
+	next := s.Iterator(isEven)
+	count := 0
+	for _, ok := next(); ok; _, ok = next() {
+		count++
+	}
+	require.Equal(t, 4, count)
+}
+
+func TestLazyStream_AppendConcat(t *testing.T) {
+	s, pulled := testLazyStream("a", "b")
+	items := []string{"c", "d"}
+	r := s.Append(items...)
+	items[0] = "x"
+	require.Equal(t, 0, *pulled)
+	r = r.Concat(Of("e"))
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, r.AsSlice())
+	require.Equal(t, 5, Of[string]().Concat(r).Len())
+}
+
+func TestLazyStream_ForEach(t *testing.T) {
+	s, pulled := testLazyStream("a", "b", "c")
+	collected := make([]string, 0)
+	err := s.ForEach(NewConsumer(func(v string) error {
+		collected = append(collected, v)
+		if v == "b" {
+			return errors.New("fooey")
+		}
+		return nil
+	}))
+	require.Error(t, err)
+	require.Equal(t, []string{"a", "b"}, collected)
+	require.Equal(t, 2, *pulled)
+	require.NoError(t, s.ForEach(nil))
+}
+
+func TestLazyStream_MaterializingOperations(t *testing.T) {
+	s, _ := testLazyStream(3, 1, 2, 3)
+	require.Equal(t, 4, s.Count(nil))
+	require.Equal(t, 2, s.Count(NewPredicate(func(v int) bool {
+		return v > 1 && v < 3 || v == 1
+	})))
+	require.Equal(t, []int{1, 2, 3, 3}, s.Sorted(IntComparator).AsSlice())
+	require.Equal(t, []int{3, 2, 1, 3}, s.Reverse().AsSlice())
+	require.Equal(t, []int{3, 1, 2}, s.Distinct().AsSlice())
+	require.Equal(t, []int{3, 1, 2}, s.Unique(IntComparator).AsSlice())
+	require.Equal(t, 3, s.LastMatch(nil).Default(0))
+	require.Equal(t, []int{2, 3}, s.Page(2, 2).Elements.AsSlice())
+	require.Equal(t, 2, s.Paginate(3).Len())
+	require.Equal(t, 3, s.Max(IntComparator).Default(0))
+	require.Equal(t, 1, s.Min(IntComparator).Default(0))
+	mn, mx := s.MinMax(IntComparator)
+	require.Equal(t, 1, mn.Default(0))
+	require.Equal(t, 3, mx.Default(0))
+	other := Of(2, 4)
+	require.Equal(t, []int{3, 1, 3}, s.Difference(other, IntComparator).AsSlice())
+	require.Equal(t, []int{2}, s.Intersection(other, IntComparator).AsSlice())
+	require.Equal(t, []int{3, 1, 3, 4}, s.SymmetricDifference(other, IntComparator).AsSlice())
+	require.Equal(t, []int{3, 1, 2, 3, 4}, s.Union(other, IntComparator).AsSlice())
+}
+
+func TestLazyStream_Peek(t *testing.T) {
+	s, pulled := testLazyStream("a", "b", "c")
+	peeked := make([]string, 0)
+	s2 := s.Peek(NewConsumer(func(v string) error {
+		peeked = append(peeked, v)
+		return nil
+	}))
+	require.Equal(t, 0, len(peeked))
+	require.Equal(t, 0, *pulled)
+	require.Equal(t, []string{"a"}, s2.Limit(1).AsSlice())
+	require.Equal(t, []string{"a"}, peeked)
+	require.Equal(t, 3, s2.Len())
+	require.Equal(t, []string{"a", "b", "c"}, peeked)
+	require.Equal(t, 3, s.Peek(nil).Len())
+}
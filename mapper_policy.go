@@ -0,0 +1,153 @@
+package streams
+
+import (
+	"errors"
+)
+
+// ErrorPolicy is the policy used by a PolicyMapper to determine how Converter errors are handled
+//
+// the available policies are FailFast, SkipAndRecord and CollectAll (for fallback values for elements whose conversion fails,
+// use NewMapperWithFallback)
+type ErrorPolicy int
+
+const (
+	// FailFast is the ErrorPolicy that aborts mapping on the first Converter error (as Mapper does)
+	FailFast ErrorPolicy = iota
+	// SkipAndRecord is the ErrorPolicy that skips elements whose conversion fails - failures are recorded in the MapReport
+	// but Map does not return an error
+	SkipAndRecord
+	// CollectAll is the ErrorPolicy that skips elements whose conversion fails and collects all the errors - Map returns
+	// the successfully converted elements along with an Errors
+	CollectAll
+)
+
+// PolicyMapper is a Mapper whose handling of Converter errors is determined by an ErrorPolicy
+type PolicyMapper[T any, R any] interface {
+	Mapper[T, R]
+	// MapWithReport converts the values in the input Stream and produces a Stream of output types, along with
+	// a report of the conversion failures
+	//
+	// the returned stream contains the successfully converted elements (and fallback values if the mapper was created
+	// using NewMapperWithFallback)
+	MapWithReport(in Stream[T]) (Stream[R], *MapReport[T])
+}
+
+// MapReport is the report of conversion failures produced by PolicyMapper.MapWithReport
+type MapReport[T any] struct {
+	// Policy is the policy used when mapping (for mappers created using NewMapperWithFallback, the policy is SkipAndRecord)
+	Policy ErrorPolicy
+	// Processed is the number of input elements processed
+	Processed int
+	// Succeeded is the number of input elements successfully converted
+	Succeeded int
+	// Failures are the conversion failures (with the index and value of the element that failed)
+	Failures []*ElementError[T]
+}
+
+// Err returns the conversion failures as an error (or nil if there were no failures)
+//
+// for the FailFast policy, the error is the failing *ElementError - otherwise the error is an Errors
+func (r *MapReport[T]) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	} else if r.Policy == FailFast {
+		return r.Failures[0]
+	}
+	errs := make(Errors, len(r.Failures))
+	for i, f := range r.Failures {
+		errs[i] = f
+	}
+	return errs
+}
+
+// NewMapperWithPolicy creates a new PolicyMapper that will use the provided Converter and handle conversion errors
+// according to the provided ErrorPolicy
+//
+// if the provided policy is not a known policy (i.e. not FailFast, SkipAndRecord or CollectAll), FailFast is used
+//
+// NewMapperWithPolicy panics if a nil Converter is supplied
+func NewMapperWithPolicy[T any, R any](c Converter[T, R], policy ErrorPolicy) PolicyMapper[T, R] {
+	if c == nil {
+		panic("converter cannot be nil")
+	}
+	if policy != SkipAndRecord && policy != CollectAll {
+		policy = FailFast
+	}
+	return policyMapper[T, R]{
+		c:      c,
+		policy: policy,
+	}
+}
+
+// NewMapperWithFallback creates a new PolicyMapper that will use the provided Converter - where the provided fallback
+// function provides the value for elements whose conversion fails
+//
+// failures are recorded in the MapReport but Map does not return an error
+//
+// NewMapperWithFallback panics if a nil Converter or nil fallback function is supplied
+func NewMapperWithFallback[T any, R any](c Converter[T, R], fallback func(v T, err error) R) PolicyMapper[T, R] {
+	if c == nil {
+		panic("converter cannot be nil")
+	} else if fallback == nil {
+		panic("fallback cannot be nil")
+	}
+	return policyMapper[T, R]{
+		c:        c,
+		policy:   SkipAndRecord,
+		fallback: fallback,
+	}
+}
+
+type policyMapper[T any, R any] struct {
+	c        Converter[T, R]
+	policy   ErrorPolicy
+	fallback func(v T, err error) R
+}
+
+// Map converts the values in the input stream and produces a stream of output types
+//
+// the returned error depends on the policy - for FailFast, the returned stream is nil and the error is the failing *ElementError;
+// for CollectAll, the error is an Errors of all the failures; for SkipAndRecord (and fallback mappers), no error is returned
+func (m policyMapper[T, R]) Map(in Stream[T]) (Stream[R], error) {
+	r, report := m.MapWithReport(in)
+	switch m.policy {
+	case FailFast:
+		if err := report.Err(); err != nil {
+			return nil, err
+		}
+	case CollectAll:
+		return r, report.Err()
+	}
+	return r, nil
+}
+
+// MapWithReport converts the values in the input Stream and produces a Stream of output types, along with
+// a report of the conversion failures
+//
+// the returned stream contains the successfully converted elements (and fallback values if the mapper was created
+// using NewMapperWithFallback)
+func (m policyMapper[T, R]) MapWithReport(in Stream[T]) (Stream[R], *MapReport[T]) {
+	report := &MapReport[T]{
+		Policy:   m.policy,
+		Failures: make([]*ElementError[T], 0),
+	}
+	r := make([]R, 0, in.Len())
+	errStop := errors.New("stop")
+	_ = in.ForEach(NewConsumer[T](func(v T) error {
+		idx := report.Processed
+		report.Processed++
+		if a, err := m.c.Convert(v); err == nil {
+			report.Succeeded++
+			r = append(r, a)
+		} else {
+			report.Failures = append(report.Failures, &ElementError[T]{Index: idx, Value: v, Err: err})
+			if m.fallback != nil {
+				r = append(r, m.fallback(v, err))
+			} else if m.policy == FailFast {
+				return errStop
+			}
+		}
+		return nil
+	}))
+	return Of(r...), report
+}
@@ -0,0 +1,114 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+var testAtoi = NewConverter(func(v string) (int, error) {
+	return strconv.Atoi(v)
+})
+
+func TestNewMapperWithPolicyPanics(t *testing.T) {
+	require.Panics(t, func() {
+		NewMapperWithPolicy[string, int](nil, FailFast)
+	})
+}
+
+func TestNewMapperWithFallbackPanics(t *testing.T) {
+	require.Panics(t, func() {
+		NewMapperWithFallback[string, int](nil, func(v string, err error) int {
+			return -1
+		})
+	})
+	require.Panics(t, func() {
+		NewMapperWithFallback[string, int](testAtoi, nil)
+	})
+}
+
+func TestPolicyMapper_FailFast(t *testing.T) {
+	m := NewMapperWithPolicy[string, int](testAtoi, FailFast)
+	out, err := m.Map(Of("1", "2", "3"))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, out.AsSlice())
+
+	out, err = m.Map(Of("1", "x", "3", "y"))
+	require.Error(t, err)
+	require.Nil(t, out)
+	var eerr *ElementError[string]
+	require.True(t, errors.As(err, &eerr))
+	require.Equal(t, 1, eerr.Index)
+	require.Equal(t, "x", eerr.Value)
+
+	out, report := m.MapWithReport(Of("1", "x", "3", "y"))
+	require.Equal(t, []int{1}, out.AsSlice())
+	require.Equal(t, 2, report.Processed)
+	require.Equal(t, 1, report.Succeeded)
+	require.Equal(t, 1, len(report.Failures))
+
+	// unknown policies are fail fast...
+	for _, p := range []ErrorPolicy{-1, 99} {
+		m = NewMapperWithPolicy[string, int](testAtoi, p)
+		out, err = m.Map(Of("1", "x", "3", "y"))
+		require.Error(t, err)
+		require.Nil(t, out)
+		_, report = m.MapWithReport(Of("1", "x", "3", "y"))
+		require.Equal(t, FailFast, report.Policy)
+		require.Equal(t, 2, report.Processed)
+	}
+}
+
+func TestPolicyMapper_SkipAndRecord(t *testing.T) {
+	m := NewMapperWithPolicy[string, int](testAtoi, SkipAndRecord)
+	out, err := m.Map(Of("1", "x", "3", "y"))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 3}, out.AsSlice())
+
+	out, report := m.MapWithReport(Of("1", "x", "3", "y"))
+	require.Equal(t, []int{1, 3}, out.AsSlice())
+	require.Equal(t, 4, report.Processed)
+	require.Equal(t, 2, report.Succeeded)
+	require.Equal(t, 2, len(report.Failures))
+	require.Equal(t, 1, report.Failures[0].Index)
+	require.Equal(t, 3, report.Failures[1].Index)
+	require.Equal(t, "y", report.Failures[1].Value)
+	require.Error(t, report.Err())
+}
+
+func TestPolicyMapper_CollectAll(t *testing.T) {
+	m := NewMapperWithPolicy[string, int](testAtoi, CollectAll)
+	out, err := m.Map(Of("1", "x", "3", "y"))
+	require.Error(t, err)
+	require.Equal(t, []int{1, 3}, out.AsSlice())
+	errs, ok := err.(Errors)
+	require.True(t, ok)
+	require.Equal(t, 2, len(errs))
+	require.Contains(t, err.Error(), "element [1]: ")
+	require.Contains(t, err.Error(), "element [3]: ")
+
+	out, err = m.Map(Of("1", "2"))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, out.AsSlice())
+}
+
+func TestPolicyMapper_Fallback(t *testing.T) {
+	m := NewMapperWithFallback(testAtoi, func(v string, err error) int {
+		return -1
+	})
+	out, err := m.Map(Of("1", "x", "3", "y"))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, -1, 3, -1}, out.AsSlice())
+
+	_, report := m.MapWithReport(Of("1", "x", "3", "y"))
+	require.Equal(t, 4, report.Processed)
+	require.Equal(t, 2, report.Succeeded)
+	require.Equal(t, 2, len(report.Failures))
+	require.Equal(t, SkipAndRecord, report.Policy)
+}
+
+func TestMapReport_Err(t *testing.T) {
+	report := &MapReport[string]{}
+	require.NoError(t, report.Err())
+}
@@ -0,0 +1,122 @@
+package streams
+
+import "container/heap"
+
+// MergeSorted creates a new stream that is the k-way merge of the supplied streams - each of which must already be
+// sorted according to the provided comparator
+//
+// the merge is lazy - elements are only merged as they are consumed from the resulting stream (so, for example, using
+// Limit on the result only merges the consumed prefix)
+//
+// where elements from different streams are equal, elements from earlier streams are placed first
+//
+// if the provided comparator is nil, the streams are concatenated
+func MergeSorted[T any](c Comparator[T], streams ...Stream[T]) Stream[T] {
+	return newLazyStream[T](mergeSortedIterator[T](c, false, streams))
+}
+
+// MergeSortedDistinct is the same as MergeSorted, except that duplicate elements (according to the provided comparator)
+// are dropped from the resulting stream
+//
+// if the provided comparator is nil, the streams are concatenated (and no duplicates are dropped)
+func MergeSortedDistinct[T any](c Comparator[T], streams ...Stream[T]) Stream[T] {
+	return newLazyStream[T](mergeSortedIterator[T](c, true, streams))
+}
+
+func mergeSortedIterator[T any](c Comparator[T], distinct bool, streams []Stream[T]) func() (T, bool) {
+	nexts := make([]func() (T, bool), 0, len(streams))
+	for _, s := range streams {
+		if s != nil {
+			nexts = append(nexts, s.Iterator())
+		}
+	}
+	if c == nil {
+		curr := 0
+		return func() (T, bool) {
+			for ; curr < len(nexts); curr++ {
+				if v, ok := nexts[curr](); ok {
+					return v, true
+				}
+			}
+			var r T
+			return r, false
+		}
+	}
+	h := &mergeHeap[T]{
+		c: c,
+	}
+	initialised := false
+	refill := -1
+	var last T
+	hasLast := false
+	return func() (T, bool) {
+		if !initialised {
+			initialised = true
+			for i, next := range nexts {
+				if v, ok := next(); ok {
+					h.items = append(h.items, mergeHeapItem[T]{value: v, source: i})
+				}
+			}
+			heap.Init(h)
+		}
+		for {
+			// the source of the previously emitted element is only advanced when the next element is requested...
+			if refill != -1 {
+				if v, ok := nexts[refill](); ok {
+					heap.Push(h, mergeHeapItem[T]{value: v, source: refill})
+				}
+				refill = -1
+			}
+			if len(h.items) == 0 {
+				var r T
+				return r, false
+			}
+			top := heap.Pop(h).(mergeHeapItem[T])
+			refill = top.source
+			if distinct {
+				if hasLast && c.Compare(last, top.value) == 0 {
+					continue
+				}
+				last, hasLast = top.value, true
+			}
+			return top.value, true
+		}
+	}
+}
+
+type mergeHeapItem[T any] struct {
+	value  T
+	source int
+}
+
+// mergeHeap is a min-heap (according to comparator) of the current head elements of each merged stream
+type mergeHeap[T any] struct {
+	c     Comparator[T]
+	items []mergeHeapItem[T]
+}
+
+func (h *mergeHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	if r := h.c.Compare(h.items[i].value, h.items[j].value); r != 0 {
+		return r < 0
+	}
+	return h.items[i].source < h.items[j].source
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *mergeHeap[T]) Push(x any) {
+	h.items = append(h.items, x.(mergeHeapItem[T]))
+}
+
+func (h *mergeHeap[T]) Pop() any {
+	l := len(h.items) - 1
+	r := h.items[l]
+	h.items = h.items[:l]
+	return r
+}
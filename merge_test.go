@@ -0,0 +1,63 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMergeSorted(t *testing.T) {
+	s := MergeSorted[int](IntComparator, Of(1, 4, 7), Of(2, 5, 8), Of(3, 6, 9))
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, s.AsSlice())
+
+	s = MergeSorted[int](IntComparator, Of(1, 2, 2), Of[int](), nil, Streamable[int]{0, 2, 3})
+	require.Equal(t, []int{0, 1, 2, 2, 2, 3}, s.AsSlice())
+
+	s = MergeSorted[int](IntComparator)
+	require.Equal(t, 0, s.Len())
+
+	s = MergeSorted[int](nil, Of(3, 1), Of(2))
+	require.Equal(t, []int{3, 1, 2}, s.AsSlice())
+}
+
+func TestMergeSorted_Stable(t *testing.T) {
+	type item struct {
+		key    int
+		source string
+	}
+	c := NewComparator[item](func(v1, v2 item) int {
+		return IntComparator.Compare(v1.key, v2.key)
+	})
+	s := MergeSorted[item](c,
+		Of(item{1, "a"}, item{2, "a"}),
+		Of(item{1, "b"}, item{2, "b"}),
+		Of(item{1, "c"}))
+	require.Equal(t, []item{{1, "a"}, {1, "b"}, {1, "c"}, {2, "a"}, {2, "b"}}, s.AsSlice())
+}
+
+func TestMergeSorted_Lazy(t *testing.T) {
+	pulled := 0
+	counting := func(values ...int) Stream[int] {
+		next := SliceIterator(values)
+		return newLazyStream[int](func() (int, bool) {
+			v, ok := next()
+			if ok {
+				pulled++
+			}
+			return v, ok
+		})
+	}
+	s := MergeSorted[int](IntComparator, counting(1, 3, 5, 7, 9), counting(2, 4, 6, 8, 10))
+	require.Equal(t, 0, pulled)
+	require.Equal(t, []int{1, 2, 3}, s.Limit(3).AsSlice())
+	require.Equal(t, 4, pulled)
+	require.Equal(t, 10, s.Len())
+	require.Equal(t, 10, pulled)
+}
+
+func TestMergeSortedDistinct(t *testing.T) {
+	s := MergeSortedDistinct[int](IntComparator, Of(1, 2, 2, 5), Of(2, 3, 5), Of(0, 5, 6))
+	require.Equal(t, []int{0, 1, 2, 3, 5, 6}, s.AsSlice())
+
+	s = MergeSortedDistinct[int](nil, Of(1, 1), Of(1))
+	require.Equal(t, []int{1, 1, 1}, s.AsSlice())
+}
@@ -0,0 +1,155 @@
+package streams
+
+import (
+	"sort"
+)
+
+// Frequencies returns a map of the number of occurrences of each distinct element of the stream
+//
+// if the stream is nil, an empty map is returned
+func Frequencies[T comparable](s Stream[T]) map[T]int {
+	r := map[T]int{}
+	for _, v := range elementsOf(s) {
+		r[v]++
+	}
+	return r
+}
+
+// FrequenciesBy creates a new stream of pairs of each distinct element of the stream and its number of occurrences
+//
+// equality of elements is determined using the provided comparator - and the pairs are in order of the first occurrence
+// of each distinct element (the first occurrence being used as the pair value)
+//
+// if the provided comparator is nil, the result is always empty
+func FrequenciesBy[T any](s Stream[T], c Comparator[T]) Stream[Pair[T, int]] {
+	r := &stream[Pair[T, int]]{}
+	if c != nil {
+		es := elementsOf(s)
+		bag := newBagCounter[T](es, c)
+		seen := map[int]bool{}
+		for _, v := range es {
+			if k := bag.key(v); !seen[k] {
+				seen[k] = true
+				r.elements = append(r.elements, Pair[T, int]{First: v, Second: bag.count(v)})
+			}
+		}
+	}
+	return r
+}
+
+// MostCommon creates a new stream of pairs of the n most common elements of the stream and their number of occurrences
+//
+// the pairs are in descending order of occurrences - where the number of occurrences is equal, in order of first occurrence
+func MostCommon[T comparable](s Stream[T], n int) Stream[Pair[T, int]] {
+	freqs := Frequencies[T](s)
+	r := &stream[Pair[T, int]]{}
+	for _, v := range elementsOf(s) {
+		if count, ok := freqs[v]; ok {
+			r.elements = append(r.elements, Pair[T, int]{First: v, Second: count})
+			delete(freqs, v)
+		}
+	}
+	return mostCommon[T](r.elements, n)
+}
+
+// MostCommonBy creates a new stream of pairs of the n most common elements of the stream and their number of occurrences
+//
+// equality of elements is determined using the provided comparator - the pairs are in descending order of occurrences and,
+// where the number of occurrences is equal, in order of first occurrence
+//
+// if the provided comparator is nil, the result is always empty
+func MostCommonBy[T any](s Stream[T], n int, c Comparator[T]) Stream[Pair[T, int]] {
+	return mostCommon[T](FrequenciesBy[T](s, c).AsSlice(), n)
+}
+
+func mostCommon[T any](freqs []Pair[T, int], n int) Stream[Pair[T, int]] {
+	sort.SliceStable(freqs, func(i, j int) bool {
+		return freqs[i].Second > freqs[j].Second
+	})
+	return (&stream[Pair[T, int]]{elements: freqs}).Limit(n)
+}
+
+// BagUnion creates a new stream that is the multiset union of the two streams - i.e. each element occurs the maximum
+// number of times it occurs in either stream
+//
+// the result is all the elements of stream a followed by the extra occurrences in stream b
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func BagUnion[T any](a, b Stream[T], c Comparator[T]) Stream[T] {
+	r := &stream[T]{}
+	if c != nil {
+		as, bs := elementsOf(a), elementsOf(b)
+		r.elements = append(r.elements, as...)
+		aBag, bBag := newBagCounter[T](as, c), newBagCounter[T](bs, c)
+		occurs := map[int]int{}
+		for _, v := range bs {
+			k := bBag.key(v)
+			if occurs[k]++; occurs[k] > aBag.count(v) {
+				r.elements = append(r.elements, v)
+			}
+		}
+	}
+	return r
+}
+
+// BagIntersection creates a new stream that is the multiset intersection of the two streams - i.e. each element occurs the
+// minimum number of times it occurs in either stream
+//
+// the result elements are taken from stream a (in order)
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func BagIntersection[T any](a, b Stream[T], c Comparator[T]) Stream[T] {
+	return bagFilter[T](a, b, c, func(occurrence, otherCount int) bool {
+		return occurrence <= otherCount
+	})
+}
+
+// BagDifference creates a new stream that is the multiset difference of the two streams - i.e. each element occurs the
+// number of times it occurs in stream a less the number of times it occurs in stream b
+//
+// the result elements are taken from stream a (in order) - where the last occurrences are kept
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func BagDifference[T any](a, b Stream[T], c Comparator[T]) Stream[T] {
+	return bagFilter[T](a, b, c, func(occurrence, otherCount int) bool {
+		return occurrence > otherCount
+	})
+}
+
+// bagFilter keeps the elements of stream a according to the occurrence number (1 based) of each element in a and its count in b
+func bagFilter[T any](a, b Stream[T], c Comparator[T], keep func(occurrence, otherCount int) bool) Stream[T] {
+	r := &stream[T]{}
+	if c != nil {
+		as := elementsOf(a)
+		aBag, bBag := newBagCounter[T](as, c), newBagCounter[T](elementsOf(b), c)
+		occurs := map[int]int{}
+		for _, v := range as {
+			k := aBag.key(v)
+			if occurs[k]++; keep(occurs[k], bBag.count(v)) {
+				r.elements = append(r.elements, v)
+			}
+		}
+	}
+	return r
+}
+
+// bagCounter counts occurrences of elements (according to a comparator) using a sorted copy of the elements
+type bagCounter[T any] struct {
+	sorted *sortedStream[T]
+}
+
+func newBagCounter[T any](elements []T, c Comparator[T]) *bagCounter[T] {
+	return &bagCounter[T]{
+		sorted: newSortedStream[T](elements, c),
+	}
+}
+
+// count returns the number of occurrences of the value
+func (b *bagCounter[T]) count(v T) int {
+	return b.sorted.UpperBound(v) - b.sorted.LowerBound(v)
+}
+
+// key returns a key identifying the distinct value (only meaningful if the value is present)
+func (b *bagCounter[T]) key(v T) int {
+	return b.sorted.LowerBound(v)
+}
@@ -0,0 +1,75 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFrequencies(t *testing.T) {
+	f := Frequencies(Of("a", "b", "a", "c", "a", "b"))
+	require.Equal(t, map[string]int{"a": 3, "b": 2, "c": 1}, f)
+	require.Equal(t, 0, len(Frequencies[string](nil)))
+}
+
+func TestFrequenciesBy(t *testing.T) {
+	f := FrequenciesBy(Of("b", "A", "a", "B", "c", "a"), StringInsensitiveComparator)
+	require.Equal(t, []Pair[string, int]{{"b", 2}, {"A", 3}, {"c", 1}}, f.AsSlice())
+	require.Equal(t, 0, FrequenciesBy(Of("a"), nil).Len())
+	require.Equal(t, 0, FrequenciesBy(nil, StringComparator).Len())
+
+	type nonComparable struct {
+		tags []string
+	}
+	s := Of(nonComparable{tags: []string{"x"}}, nonComparable{tags: []string{"y", "z"}}, nonComparable{tags: []string{"w"}})
+	fs := FrequenciesBy(s, NewComparator(func(v1, v2 nonComparable) int {
+		return IntComparator.Compare(len(v1.tags), len(v2.tags))
+	}))
+	require.Equal(t, 2, fs.Len())
+	require.Equal(t, 2, fs.AsSlice()[0].Second)
+	require.Equal(t, 1, fs.AsSlice()[1].Second)
+}
+
+func TestMostCommon(t *testing.T) {
+	s := Of("c", "a", "b", "a", "c", "d", "a")
+	mc := MostCommon(s, 2)
+	require.Equal(t, []Pair[string, int]{{"a", 3}, {"c", 2}}, mc.AsSlice())
+	mc = MostCommon(s, 10)
+	require.Equal(t, []Pair[string, int]{{"a", 3}, {"c", 2}, {"b", 1}, {"d", 1}}, mc.AsSlice())
+	require.Equal(t, 0, MostCommon(s, 0).Len())
+	require.Equal(t, 0, MostCommon[string](nil, 2).Len())
+}
+
+func TestMostCommonBy(t *testing.T) {
+	s := Of("c", "A", "b", "a", "C", "d", "a")
+	mc := MostCommonBy(s, 2, StringInsensitiveComparator)
+	require.Equal(t, []Pair[string, int]{{"A", 3}, {"c", 2}}, mc.AsSlice())
+	require.Equal(t, 0, MostCommonBy(s, 2, nil).Len())
+}
+
+func TestBagUnion(t *testing.T) {
+	a := Of("a", "b", "a", "c")
+	b := Of("a", "a", "a", "b", "b", "d")
+	r := BagUnion(a, b, StringComparator)
+	require.Equal(t, []string{"a", "b", "a", "c", "a", "b", "d"}, r.AsSlice())
+	require.Equal(t, 0, BagUnion(a, b, nil).Len())
+	require.Equal(t, []string{"a", "b", "a", "c"}, BagUnion(a, nil, StringComparator).AsSlice())
+}
+
+func TestBagIntersection(t *testing.T) {
+	a := Of("a", "b", "a", "c", "a")
+	b := Of("a", "a", "b", "b", "d")
+	r := BagIntersection(a, b, StringComparator)
+	require.Equal(t, []string{"a", "b", "a"}, r.AsSlice())
+	require.Equal(t, 0, BagIntersection(a, b, nil).Len())
+	require.Equal(t, 0, BagIntersection(a, nil, StringComparator).Len())
+}
+
+func TestBagDifference(t *testing.T) {
+	a := Of("a", "b", "a", "c", "a")
+	b := Of("a", "b", "b", "d")
+	r := BagDifference(a, b, StringComparator)
+	require.Equal(t, []string{"a", "c", "a"}, r.AsSlice())
+	require.Equal(t, 0, BagDifference(a, b, nil).Len())
+	require.Equal(t, []string{"a", "b", "a", "c", "a"}, BagDifference(a, nil, StringComparator).AsSlice())
+	require.Equal(t, 0, BagDifference(nil, b, StringComparator).Len())
+}
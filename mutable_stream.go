@@ -0,0 +1,138 @@
+package streams
+
+import (
+	"github.com/go-andiamo/gopt"
+	"sort"
+)
+
+// MutableStream is a Stream whose elements can be changed in place
+//
+// A MutableStream is returned by NewStreamableSlice - and the mutating methods change the underlying slice, so
+// owners of the pointer to the slice observe the changes
+type MutableStream[T any] interface {
+	Stream[T]
+	// Dedupe removes (in place) duplicate elements - keeping the first occurrence of each - returning the number of elements removed
+	//
+	// equality of elements is determined using the provided comparator (if the provided comparator is nil, nothing is removed)
+	Dedupe(c Comparator[T]) int
+	// InsertAt inserts (in place) the specified items at the specified index
+	//
+	// if the index is less than zero, the items are inserted at the start - if the index is greater than the length, the items are appended
+	InsertAt(i int, items ...T) MutableStream[T]
+	// RemoveAt removes (in place) the element at the specified index - returning an optional of the removed element
+	//
+	// if the index is out of range, nothing is removed and an empty (not present) optional is returned
+	RemoveAt(i int) *gopt.Optional[T]
+	// RemoveIf removes (in place) all elements that match the provided predicate - returning the number of elements removed
+	//
+	// if the provided predicate is nil, nothing is removed
+	RemoveIf(p Predicate[T]) int
+	// ReplaceAll replaces (in place) each element with the result of the provided function
+	//
+	// if the provided function is nil, nothing is replaced
+	ReplaceAll(f func(v T) T) MutableStream[T]
+	// ReverseInPlace reverses (in place) the order of the elements
+	ReverseInPlace() MutableStream[T]
+	// SortInPlace sorts (in place) the elements according to the provided comparator
+	//
+	// the sort is stable - and if the provided comparator is nil, the elements are not sorted
+	SortInPlace(c Comparator[T]) MutableStream[T]
+}
+
+// Dedupe removes (in place) duplicate elements - keeping the first occurrence of each - returning the number of elements removed
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, nothing is removed)
+func (s *streamableSlice[T]) Dedupe(c Comparator[T]) int {
+	if c == nil {
+		return 0
+	}
+	kept := 0
+	return removeIf[T](s.elements, NewPredicate(func(v T) bool {
+		for _, k := range (*s.elements)[:kept] {
+			if c.Compare(k, v) == 0 {
+				return true
+			}
+		}
+		kept++
+		return false
+	}))
+}
+
+// InsertAt inserts (in place) the specified items at the specified index
+//
+// if the index is less than zero, the items are inserted at the start - if the index is greater than the length, the items are appended
+func (s *streamableSlice[T]) InsertAt(i int, items ...T) MutableStream[T] {
+	if len(items) > 0 {
+		es := *s.elements
+		i = absZero(i)
+		if i > len(es) {
+			i = len(es)
+		}
+		r := make([]T, 0, len(es)+len(items))
+		r = append(r, es[:i]...)
+		r = append(r, items...)
+		*s.elements = append(r, es[i:]...)
+	}
+	return s
+}
+
+// RemoveAt removes (in place) the element at the specified index - returning an optional of the removed element
+//
+// if the index is out of range, nothing is removed and an empty (not present) optional is returned
+func (s *streamableSlice[T]) RemoveAt(i int) *gopt.Optional[T] {
+	es := *s.elements
+	if i < 0 || i >= len(es) {
+		return gopt.Empty[T]()
+	}
+	r := es[i]
+	copy(es[i:], es[i+1:])
+	var zero T
+	es[len(es)-1] = zero
+	*s.elements = es[:len(es)-1]
+	return gopt.Of[T](r)
+}
+
+// RemoveIf removes (in place) all elements that match the provided predicate - returning the number of elements removed
+//
+// if the provided predicate is nil, nothing is removed
+func (s *streamableSlice[T]) RemoveIf(p Predicate[T]) int {
+	if p == nil {
+		return 0
+	}
+	return removeIf[T](s.elements, p)
+}
+
+// ReplaceAll replaces (in place) each element with the result of the provided function
+//
+// if the provided function is nil, nothing is replaced
+func (s *streamableSlice[T]) ReplaceAll(f func(v T) T) MutableStream[T] {
+	if f != nil {
+		es := *s.elements
+		for i, v := range es {
+			es[i] = f(v)
+		}
+	}
+	return s
+}
+
+// ReverseInPlace reverses (in place) the order of the elements
+func (s *streamableSlice[T]) ReverseInPlace() MutableStream[T] {
+	es := *s.elements
+	for i, j := 0, len(es)-1; i < j; i, j = i+1, j-1 {
+		es[i], es[j] = es[j], es[i]
+	}
+	return s
+}
+
+// SortInPlace sorts (in place) the elements according to the provided comparator
+//
+// the sort is stable - and if the provided comparator is nil, the elements are not sorted
+func (s *streamableSlice[T]) SortInPlace(c Comparator[T]) MutableStream[T] {
+	if c != nil {
+		es := *s.elements
+		sort.SliceStable(es, func(i, j int) bool {
+			return c.Less(es[i], es[j])
+		})
+	}
+	return s
+}
@@ -0,0 +1,89 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestStreamableSlice_Dedupe(t *testing.T) {
+	sl := &[]string{"a", "B", "A", "c", "b", "C", "d"}
+	s := NewStreamableSlice(sl)
+	require.Equal(t, 0, s.Dedupe(nil))
+	require.Equal(t, 7, len(*sl))
+	n := s.Dedupe(StringInsensitiveComparator)
+	require.Equal(t, 3, n)
+	require.Equal(t, []string{"a", "B", "c", "d"}, *sl)
+	require.Equal(t, 4, s.Len())
+}
+
+func TestStreamableSlice_InsertAt(t *testing.T) {
+	sl := &[]string{"a", "b", "c"}
+	s := NewStreamableSlice(sl)
+	s.InsertAt(1, "x", "y")
+	require.Equal(t, []string{"a", "x", "y", "b", "c"}, *sl)
+	s.InsertAt(-1, "start")
+	require.Equal(t, []string{"start", "a", "x", "y", "b", "c"}, *sl)
+	s.InsertAt(100, "end")
+	require.Equal(t, []string{"start", "a", "x", "y", "b", "c", "end"}, *sl)
+	s.InsertAt(1)
+	require.Equal(t, 7, s.Len())
+}
+
+func TestStreamableSlice_RemoveAt(t *testing.T) {
+	sl := &[]string{"a", "b", "c"}
+	s := NewStreamableSlice(sl)
+	o := s.RemoveAt(1)
+	require.True(t, o.IsPresent())
+	v, _ := o.GetOk()
+	require.Equal(t, "b", v)
+	require.Equal(t, []string{"a", "c"}, *sl)
+	require.False(t, s.RemoveAt(-1).IsPresent())
+	require.False(t, s.RemoveAt(2).IsPresent())
+	require.True(t, s.RemoveAt(1).IsPresent())
+	require.True(t, s.RemoveAt(0).IsPresent())
+	require.Equal(t, 0, len(*sl))
+	require.False(t, s.RemoveAt(0).IsPresent())
+}
+
+func TestStreamableSlice_RemoveIf(t *testing.T) {
+	sl := &[]string{"a", "B", "c", "D"}
+	s := NewStreamableSlice(sl)
+	require.Equal(t, 0, s.RemoveIf(nil))
+	n := s.RemoveIf(NewPredicate(func(v string) bool {
+		return strings.ToUpper(v) == v
+	}))
+	require.Equal(t, 2, n)
+	require.Equal(t, []string{"a", "c"}, *sl)
+}
+
+func TestStreamableSlice_ReplaceAll(t *testing.T) {
+	sl := &[]string{"a", "b", "c"}
+	s := NewStreamableSlice(sl)
+	s.ReplaceAll(nil)
+	require.Equal(t, []string{"a", "b", "c"}, *sl)
+	s.ReplaceAll(strings.ToUpper)
+	require.Equal(t, []string{"A", "B", "C"}, *sl)
+}
+
+func TestStreamableSlice_ReverseInPlace(t *testing.T) {
+	sl := &[]string{"a", "b", "c", "d"}
+	s := NewStreamableSlice(sl)
+	s.ReverseInPlace()
+	require.Equal(t, []string{"d", "c", "b", "a"}, *sl)
+	sl = &[]string{}
+	s = NewStreamableSlice(sl)
+	s.ReverseInPlace()
+	require.Equal(t, 0, len(*sl))
+}
+
+func TestStreamableSlice_SortInPlace(t *testing.T) {
+	sl := &[]string{"c", "B", "a", "b", "A"}
+	s := NewStreamableSlice(sl)
+	s.SortInPlace(nil)
+	require.Equal(t, []string{"c", "B", "a", "b", "A"}, *sl)
+	s.SortInPlace(StringInsensitiveComparator)
+	require.Equal(t, []string{"a", "A", "B", "b", "c"}, *sl)
+	s.SortInPlace(StringComparator.Reversed()).ReverseInPlace()
+	require.Equal(t, []string{"A", "B", "a", "b", "c"}, *sl)
+}
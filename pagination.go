@@ -0,0 +1,224 @@
+package streams
+
+import (
+	"github.com/go-andiamo/gopt"
+	"sort"
+)
+
+// Page is a page of elements of a stream - as returned by Stream.Page or Pages.Next
+type Page[T any] struct {
+	// Elements is the elements of the page
+	Elements Stream[T]
+	// Number is the page number (1 based)
+	Number int
+	// Size is the (requested) page size
+	Size int
+	// Total is the total number of elements in the stream
+	Total int
+	// TotalPages is the total number of pages in the stream
+	TotalPages int
+	// HasNext indicates whether there is a page after this page (always false if the page number is out of range)
+	HasNext bool
+	// HasPrevious indicates whether there is a page before this page (always false if the page number is out of range)
+	HasPrevious bool
+}
+
+// Pages is an iterator over the pages of a stream - as returned by Stream.Paginate
+//
+// for example
+//  pages := s.Paginate(10)
+//  for page, ok := pages.Next(); ok; page, ok = pages.Next() {
+//      fmt.Println(page.Number, page.Elements.AsSlice())
+//  }
+type Pages[T any] interface {
+	// Next returns the next page - or false if there are no more pages
+	Next() (Page[T], bool)
+	// Len returns the total number of pages
+	Len() int
+	// Reset resets the iterator to before the first page
+	Reset()
+}
+
+func newPage[T any](elements []T, n int, size int) Page[T] {
+	return newRangePage(len(elements), n, size, func(start, end int) []T {
+		return append(make([]T, 0, end-start), elements[start:end]...)
+	})
+}
+
+// newRangePage creates a page from a total number of elements - where the range function provides a copy of the
+// elements from start (inclusive) to end (exclusive)
+func newRangePage[T any](total int, n int, size int, rng func(start, end int) []T) Page[T] {
+	r := Page[T]{
+		Number: n,
+		Size:   size,
+		Total:  total,
+	}
+	if size < 1 {
+		r.Elements = &stream[T]{elements: make([]T, 0)}
+		return r
+	}
+	r.TotalPages = (total + size - 1) / size
+	if n < 1 || n > r.TotalPages {
+		r.Elements = &stream[T]{elements: make([]T, 0)}
+	} else {
+		r.HasNext = n < r.TotalPages
+		r.HasPrevious = n > 1
+		start := (n - 1) * size
+		end := start + size
+		if end > total {
+			end = total
+		}
+		r.Elements = &stream[T]{elements: rng(start, end)}
+	}
+	return r
+}
+
+type pages[T any] struct {
+	total int
+	rng   func(start, end int) []T
+	size  int
+	curr  int
+}
+
+func newPages[T any](elements []T, size int) Pages[T] {
+	return newRangePages(len(elements), size, func(start, end int) []T {
+		return append(make([]T, 0, end-start), elements[start:end]...)
+	})
+}
+
+func newRangePages[T any](total int, size int, rng func(start, end int) []T) Pages[T] {
+	return &pages[T]{
+		total: total,
+		rng:   rng,
+		size:  size,
+	}
+}
+
+func (p *pages[T]) Next() (Page[T], bool) {
+	if p.curr >= p.Len() {
+		return Page[T]{}, false
+	}
+	p.curr++
+	return newRangePage(p.total, p.curr, p.size, p.rng), true
+}
+
+func (p *pages[T]) Len() int {
+	if p.size < 1 {
+		return 0
+	}
+	return (p.total + p.size - 1) / p.size
+}
+
+func (p *pages[T]) Reset() {
+	p.curr = 0
+}
+
+// CursorPage is a page of elements of a stream - as returned by PageAfter
+type CursorPage[T any, K any] struct {
+	// Elements is the elements of the page
+	Elements Stream[T]
+	// Total is the total number of elements in the stream
+	Total int
+	// HasNext indicates whether there are elements after this page
+	HasNext bool
+	// Next is the cursor for the next page (i.e. the position of the last element of this page) - empty (not present)
+	// if there are no elements after this page
+	Next *gopt.Optional[Cursor[K]]
+}
+
+// Cursor is a position in a stream sorted by key - as used by PageAfter
+type Cursor[K any] struct {
+	// Key is the key of the last-seen element
+	Key K
+	// Index is the position (0 based) of the last-seen element amongst the elements with the same key - so, where keys
+	// are unique, it is always 0
+	Index int
+}
+
+// PageAfter returns a page of up to size elements of the stream (sorted by key) that are after the after cursor - this
+// provides cursor (keyset) based pagination, where the next page is obtained by resuming after the last-seen element, for example
+//  page := PageAfter(s, key, c, nil, 10)
+//  for {
+//      fmt.Println(page.Elements.AsSlice())
+//      if !page.HasNext {
+//          break
+//      }
+//      page = PageAfter(s, key, c, page.Next, 10)
+//  }
+//
+// the stream elements are (stably) sorted by key using the provided comparator - so the stream does not need to be already
+// sorted, and elements with the same key are paged in stream order (the cursor records the position amongst them)
+//
+// the key function is called once per element - and the elements are only sorted if they are not already in key order
+//
+// if the after cursor is nil or empty (not present), the first page is returned
+//
+// if the provided key function or comparator is nil, or the size is less than 1, the page is empty
+func PageAfter[T any, K any](s Stream[T], key func(v T) K, c Comparator[K], after *gopt.Optional[Cursor[K]], size int) CursorPage[T, K] {
+	elements := elementsOf(s)
+	r := CursorPage[T, K]{
+		Elements: &stream[T]{elements: make([]T, 0)},
+		Total:    len(elements),
+		Next:     gopt.Empty[Cursor[K]](),
+	}
+	if key == nil || c == nil || size < 1 {
+		return r
+	}
+	sorted, keys := sortedByKey(elements, key, c)
+	// first returns the index of the first element whose key is not less than k...
+	first := func(k K) int {
+		return sort.Search(len(sorted), func(i int) bool {
+			return !c.Less(keys[i], k)
+		})
+	}
+	start := 0
+	if after != nil {
+		if cur, ok := after.GetOk(); ok {
+			start = first(cur.Key) + absZero(cur.Index) + 1
+			if next := sort.Search(len(sorted), func(i int) bool {
+				return c.Greater(keys[i], cur.Key)
+			}); start > next {
+				start = next
+			}
+		}
+	}
+	end := start + size
+	if end >= len(sorted) {
+		end = len(sorted)
+	} else {
+		r.HasNext = true
+	}
+	r.Elements = &stream[T]{elements: append(make([]T, 0, end-start), sorted[start:end]...)}
+	if r.HasNext {
+		k := keys[end-1]
+		r.Next = gopt.Of(Cursor[K]{Key: k, Index: end - 1 - first(k)})
+	}
+	return r
+}
+
+// sortedByKey returns the elements (stably) sorted by key - along with the keys of the sorted elements
+func sortedByKey[T any, K any](elements []T, key func(v T) K, c Comparator[K]) ([]T, []K) {
+	keys := make([]K, len(elements))
+	for i, v := range elements {
+		keys[i] = key(v)
+	}
+	less := func(i, j int) bool {
+		return c.Less(keys[i], keys[j])
+	}
+	if sort.SliceIsSorted(keys, less) {
+		return elements, keys
+	}
+	idx := make([]int, len(elements))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return less(idx[i], idx[j])
+	})
+	sorted := make([]T, len(elements))
+	sortedKeys := make([]K, len(elements))
+	for i, x := range idx {
+		sorted[i], sortedKeys[i] = elements[x], keys[x]
+	}
+	return sorted, sortedKeys
+}
@@ -0,0 +1,203 @@
+package streams
+
+import (
+	"github.com/go-andiamo/gopt"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPage(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5, 6, 7)
+	testCases := []struct {
+		n           int
+		size        int
+		expect      []int
+		totalPages  int
+		hasNext     bool
+		hasPrevious bool
+	}{
+		{1, 3, []int{1, 2, 3}, 3, true, false},
+		{2, 3, []int{4, 5, 6}, 3, true, true},
+		{3, 3, []int{7}, 3, false, true},
+		{4, 3, []int{}, 3, false, false},
+		{10, 3, []int{}, 3, false, false},
+		{0, 3, []int{}, 3, false, false},
+		{-1, 3, []int{}, 3, false, false},
+		{1, 7, []int{1, 2, 3, 4, 5, 6, 7}, 1, false, false},
+		{1, 10, []int{1, 2, 3, 4, 5, 6, 7}, 1, false, false},
+		{1, 0, []int{}, 0, false, false},
+		{1, -1, []int{}, 0, false, false},
+	}
+	for i, tc := range testCases {
+		p := s.Page(tc.n, tc.size)
+		require.Equal(t, tc.expect, p.Elements.AsSlice(), i)
+		require.Equal(t, tc.n, p.Number, i)
+		require.Equal(t, tc.size, p.Size, i)
+		require.Equal(t, 7, p.Total, i)
+		require.Equal(t, tc.totalPages, p.TotalPages, i)
+		require.Equal(t, tc.hasNext, p.HasNext, i)
+		require.Equal(t, tc.hasPrevious, p.HasPrevious, i)
+	}
+
+	p := Of[int]().Page(1, 10)
+	require.Equal(t, 0, p.Elements.Len())
+	require.Equal(t, 0, p.TotalPages)
+	require.False(t, p.HasNext)
+	require.False(t, p.HasPrevious)
+}
+
+func TestPages(t *testing.T) {
+	pages := Of(1, 2, 3, 4, 5, 6, 7).Paginate(3)
+	require.Equal(t, 3, pages.Len())
+	for n := 1; n <= 3; n++ {
+		p, ok := pages.Next()
+		require.True(t, ok)
+		require.Equal(t, n, p.Number)
+		require.Equal(t, 3, p.TotalPages)
+		require.Equal(t, n < 3, p.HasNext)
+	}
+	_, ok := pages.Next()
+	require.False(t, ok)
+	pages.Reset()
+	p, ok := pages.Next()
+	require.True(t, ok)
+	require.Equal(t, []int{1, 2, 3}, p.Elements.AsSlice())
+
+	pages = Of(1, 2, 3).Paginate(0)
+	require.Equal(t, 0, pages.Len())
+	_, ok = pages.Next()
+	require.False(t, ok)
+	pages = Of[int]().Paginate(10)
+	require.Equal(t, 0, pages.Len())
+	_, ok = pages.Next()
+	require.False(t, ok)
+}
+
+type pagedItem struct {
+	id   int
+	name string
+}
+
+func TestPageAfter(t *testing.T) {
+	s := Of(pagedItem{5, "e"}, pagedItem{2, "b"}, pagedItem{4, "d"}, pagedItem{1, "a"}, pagedItem{3, "c"})
+	key := func(v pagedItem) int {
+		return v.id
+	}
+	names := func(p CursorPage[pagedItem, int]) []string {
+		r := make([]string, 0)
+		for _, v := range p.Elements.AsSlice() {
+			r = append(r, v.name)
+		}
+		return r
+	}
+
+	p := PageAfter(s, key, IntComparator, nil, 2)
+	require.Equal(t, []string{"a", "b"}, names(p))
+	require.Equal(t, 5, p.Total)
+	require.True(t, p.HasNext)
+	require.Equal(t, 2, p.Next.OrElse(Cursor[int]{}).Key)
+	p = PageAfter(s, key, IntComparator, p.Next, 2)
+	require.Equal(t, []string{"c", "d"}, names(p))
+	require.True(t, p.HasNext)
+	require.Equal(t, 4, p.Next.OrElse(Cursor[int]{}).Key)
+	p = PageAfter(s, key, IntComparator, p.Next, 2)
+	require.Equal(t, []string{"e"}, names(p))
+	require.False(t, p.HasNext)
+	require.False(t, p.Next.IsPresent())
+
+	// cursor keys do not need to be present in the stream...
+	p = PageAfter(s, key, IntComparator, gopt.Of(Cursor[int]{Key: 0}), 2)
+	require.Equal(t, []string{"a", "b"}, names(p))
+	p = PageAfter(s, key, IntComparator, gopt.Of(Cursor[int]{Key: 5}), 2)
+	require.Equal(t, []string{}, names(p))
+	require.False(t, p.HasNext)
+	p = PageAfter(s, key, IntComparator.Reversed(), gopt.Of(Cursor[int]{Key: 4}), 2)
+	require.Equal(t, []string{"c", "b"}, names(p))
+	require.True(t, p.HasNext)
+	p = PageAfter(s, key, IntComparator, gopt.Empty[Cursor[int]](), 5)
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, names(p))
+	require.False(t, p.HasNext)
+
+	p = PageAfter(s, nil, IntComparator, nil, 2)
+	require.Equal(t, 0, p.Elements.Len())
+	p = PageAfter(s, key, nil, nil, 2)
+	require.Equal(t, 0, p.Elements.Len())
+	p = PageAfter(s, key, IntComparator, nil, 0)
+	require.Equal(t, 0, p.Elements.Len())
+	p = PageAfter(nil, key, IntComparator, nil, 2)
+	require.Equal(t, 0, p.Elements.Len())
+	require.Equal(t, 0, p.Total)
+}
+
+func TestPageAfter_DuplicateKeys(t *testing.T) {
+	s := Of(pagedItem{1, "a"}, pagedItem{2, "b"}, pagedItem{2, "c"}, pagedItem{3, "d"}, pagedItem{4, "e"})
+	calls := 0
+	key := func(v pagedItem) int {
+		calls++
+		return v.id
+	}
+	names := make([]string, 0)
+	pages := 0
+	var after *gopt.Optional[Cursor[int]]
+	for {
+		p := PageAfter(s, key, IntComparator, after, 2)
+		pages++
+		for _, v := range p.Elements.AsSlice() {
+			names = append(names, v.name)
+		}
+		if !p.HasNext {
+			break
+		}
+		after = p.Next
+	}
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, names)
+	require.Equal(t, 3, pages)
+	// keys are only computed once per element per page...
+	require.Equal(t, 15, calls)
+
+	p := PageAfter(s, key, IntComparator, nil, 2)
+	require.Equal(t, Cursor[int]{Key: 2, Index: 0}, p.Next.OrElse(Cursor[int]{}))
+	p = PageAfter(s, key, IntComparator, p.Next, 2)
+	require.Equal(t, Cursor[int]{Key: 3, Index: 0}, p.Next.OrElse(Cursor[int]{}))
+	p = PageAfter(s, key, IntComparator, gopt.Of(Cursor[int]{Key: 2, Index: 1}), 2)
+	require.Equal(t, []pagedItem{{3, "d"}, {4, "e"}}, p.Elements.AsSlice())
+	// index beyond the elements with the same key...
+	p = PageAfter(s, key, IntComparator, gopt.Of(Cursor[int]{Key: 2, Index: 5}), 2)
+	require.Equal(t, []pagedItem{{3, "d"}, {4, "e"}}, p.Elements.AsSlice())
+
+	// unsorted duplicates are paged in stream order...
+	s = Of(pagedItem{2, "b"}, pagedItem{1, "a"}, pagedItem{2, "c"}, pagedItem{2, "d"}, pagedItem{0, "z"})
+	p = PageAfter(s, key, IntComparator, gopt.Of(Cursor[int]{Key: 1}), 2)
+	require.Equal(t, []pagedItem{{2, "b"}, {2, "c"}}, p.Elements.AsSlice())
+	require.Equal(t, Cursor[int]{Key: 2, Index: 1}, p.Next.OrElse(Cursor[int]{}))
+	p = PageAfter(s, key, IntComparator, p.Next, 2)
+	require.Equal(t, []pagedItem{{2, "d"}}, p.Elements.AsSlice())
+	require.False(t, p.HasNext)
+}
+
+func TestPageAfter_SortedStream(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5, 6).Sorted(IntComparator)
+	negate := func(v int) int {
+		return -v
+	}
+	// the key does not follow the sorted stream order - so all elements are still paged (in key order)...
+	pages := make([][]int, 0)
+	var after *gopt.Optional[Cursor[int]]
+	for {
+		p := PageAfter[int, int](s, negate, IntComparator, after, 2)
+		pages = append(pages, p.Elements.AsSlice())
+		if !p.HasNext {
+			break
+		}
+		after = p.Next
+	}
+	require.Equal(t, [][]int{{6, 5}, {4, 3}, {2, 1}}, pages)
+
+	identity := func(v int) int {
+		return v
+	}
+	p := PageAfter[int, int](s, identity, IntComparator, gopt.Of(Cursor[int]{Key: 2}), 3)
+	require.Equal(t, []int{3, 4, 5}, p.Elements.AsSlice())
+	p = PageAfter[int, int](s, identity, IntComparator.Reversed(), gopt.Of(Cursor[int]{Key: 5}), 3)
+	require.Equal(t, []int{4, 3, 2}, p.Elements.AsSlice())
+}
@@ -0,0 +1,650 @@
+package streams
+
+import (
+	"github.com/go-andiamo/gopt"
+)
+
+// PersistentStream is a truly immutable Stream - no operation on it ever changes its elements, and no other stream
+// or slice shares (aliases) its elements storage
+//
+// A PersistentStream is a persistent (balanced tree) vector, so PersistentStream.Prepend, PersistentStream.Set and also
+// Stream.Append and Stream.Concat (which return a PersistentStream) are cheap - the new stream structurally shares all
+// unchanged parts with the original
+//
+// Because it is immutable, a PersistentStream can be freely shared across goroutines
+type PersistentStream[T any] interface {
+	Stream[T]
+	// Get returns an optional of the element at the specified index
+	//
+	// if the index is out of range, an empty (not present) optional is returned
+	Get(i int) *gopt.Optional[T]
+	// Prepend creates a new persistent stream with the specified elements followed by all the elements of this stream
+	Prepend(items ...T) PersistentStream[T]
+	// Set creates a new persistent stream with the element at the specified index replaced by the specified value
+	//
+	// if the index is out of range, this stream is returned
+	Set(i int, v T) PersistentStream[T]
+}
+
+// NewPersistentStream creates a new PersistentStream of the specified values
+func NewPersistentStream[T any](values ...T) PersistentStream[T] {
+	return &persistentStream[T]{
+		root: buildPersistentNode[T](values),
+	}
+}
+
+type persistentStream[T any] struct {
+	root *persistentNode[T]
+}
+
+// flat returns a (non-persistent) stream of a copy of the elements
+func (s *persistentStream[T]) flat() *stream[T] {
+	return &stream[T]{
+		elements: s.root.appendTo(make([]T, 0, s.root.len())),
+	}
+}
+
+// rangeOf returns a copy of the elements from start (inclusive) to end (exclusive) - without flattening the whole tree
+func (s *persistentStream[T]) rangeOf(start, end int) []T {
+	return s.root.appendRange(make([]T, 0, end-start), start, end)
+}
+
+// Get returns an optional of the element at the specified index
+//
+// if the index is out of range, an empty (not present) optional is returned
+func (s *persistentStream[T]) Get(i int) *gopt.Optional[T] {
+	if i < 0 || i >= s.root.len() {
+		return gopt.Empty[T]()
+	}
+	return gopt.Of[T](s.root.get(i))
+}
+
+// Prepend creates a new persistent stream with the specified elements followed by all the elements of this stream
+func (s *persistentStream[T]) Prepend(items ...T) PersistentStream[T] {
+	return &persistentStream[T]{
+		root: concatPersistentNodes(buildPersistentNode[T](items), s.root),
+	}
+}
+
+// Set creates a new persistent stream with the element at the specified index replaced by the specified value
+//
+// if the index is out of range, this stream is returned
+func (s *persistentStream[T]) Set(i int, v T) PersistentStream[T] {
+	if i < 0 || i >= s.root.len() {
+		return s
+	}
+	return &persistentStream[T]{
+		root: s.root.set(i, v),
+	}
+}
+
+// Append creates a new stream with all the elements of this stream followed by the specified elements
+//
+// the returned stream is a PersistentStream
+func (s *persistentStream[T]) Append(items ...T) Stream[T] {
+	return &persistentStream[T]{
+		root: concatPersistentNodes(s.root, buildPersistentNode[T](items)),
+	}
+}
+
+// AsSlice returns a copy of the elements
+func (s *persistentStream[T]) AsSlice() []T {
+	return s.flat().elements
+}
+
+// Concat creates a new stream with all the elements of this stream followed by all the elements of the added stream
+//
+// the returned stream is a PersistentStream
+func (s *persistentStream[T]) Concat(add Stream[T]) Stream[T] {
+	var other *persistentNode[T]
+	if ps, ok := add.(*persistentStream[T]); ok {
+		other = ps.root
+	} else if add != nil {
+		other = buildPersistentNode[T](add.AsSlice())
+	}
+	return &persistentStream[T]{
+		root: concatPersistentNodes(s.root, other),
+	}
+}
+
+// Len returns the length (number of elements) of this stream
+func (s *persistentStream[T]) Len() int {
+	return s.root.len()
+}
+
+// AllMatch returns whether all elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *persistentStream[T]) AllMatch(p Predicate[T]) bool {
+	if p == nil || s.root == nil {
+		return false
+	}
+	return s.root.each(p.Test)
+}
+
+// AnyMatch returns whether any elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *persistentStream[T]) AnyMatch(p Predicate[T]) bool {
+	return p != nil && !s.root.each(func(v T) bool {
+		return !p.Test(v)
+	})
+}
+
+// Count returns the count of elements that match the provided predicate
+//
+// If the predicate is nil, returns the count of all elements
+func (s *persistentStream[T]) Count(p Predicate[T]) int {
+	if p == nil {
+		return s.root.len()
+	}
+	c := 0
+	s.root.each(func(v T) bool {
+		if p.Test(v) {
+			c++
+		}
+		return true
+	})
+	return c
+}
+
+// Difference creates a new stream that is the set difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *persistentStream[T]) Difference(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.flat().Difference(other, c)
+}
+
+// Distinct creates a new stream of distinct elements in this stream
+//
+// elements whose values are not hashable (e.g. slices, maps or funcs) are compared using reflect.DeepEqual
+func (s *persistentStream[T]) Distinct() Stream[T] {
+	return s.flat().Distinct()
+}
+
+// Filter creates a new stream of elements in this stream that match the provided predicate
+//
+// if the provided predicate is nil, all elements in this stream are returned
+func (s *persistentStream[T]) Filter(p Predicate[T]) Stream[T] {
+	return s.flat().Filter(p)
+}
+
+// FirstMatch returns an optional of the first element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the first element in this stream is returned
+func (s *persistentStream[T]) FirstMatch(p Predicate[T]) *gopt.Optional[T] {
+	r := gopt.Empty[T]()
+	s.root.each(func(v T) bool {
+		if p == nil || p.Test(v) {
+			r = gopt.Of[T](v)
+			return false
+		}
+		return true
+	})
+	return r
+}
+
+// ForEach performs an action on each element of this stream
+//
+// the action to be performed is defined by the provided consumer
+//
+// if the provided consumer is nil, nothing is performed
+func (s *persistentStream[T]) ForEach(c Consumer[T]) error {
+	var err error
+	if c != nil {
+		s.root.each(func(v T) bool {
+			err = c.Accept(v)
+			return err == nil
+		})
+	}
+	return err
+}
+
+// Has returns whether this stream contains an element that is equal to the element value provided
+//
+// equality is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false
+func (s *persistentStream[T]) Has(v T, c Comparator[T]) bool {
+	return c != nil && !s.root.each(func(v2 T) bool {
+		return c.Compare(v, v2) != 0
+	})
+}
+
+// Intersection creates a new stream that is the set intersection of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *persistentStream[T]) Intersection(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.flat().Intersection(other, c)
+}
+
+// Iterator returns an iterator (pull) function
+//
+// the iterator function can be used in for loops, for example
+//  next := strm.Iterator()
+//  for v, ok := next(); ok; v, ok = next() {
+//      fmt.Println(v)
+//  }
+//
+// Iterator can also optionally accept varargs of Predicate - which, if specified, are logically OR-ed on each pull to ensure
+// that pulled elements match
+func (s *persistentStream[T]) Iterator(ps ...Predicate[T]) func() (T, bool) {
+	next := s.root.iterator()
+	if p := joinPredicates[T](ps...); p != nil {
+		return func() (T, bool) {
+			for v, ok := next(); ok; v, ok = next() {
+				if p.Test(v) {
+					return v, true
+				}
+			}
+			var r T
+			return r, false
+		}
+	}
+	return next
+}
+
+// LastMatch returns an optional of the last element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the last element in this stream is returned
+func (s *persistentStream[T]) LastMatch(p Predicate[T]) *gopt.Optional[T] {
+	r := gopt.Empty[T]()
+	s.root.eachReverse(func(v T) bool {
+		if p == nil || p.Test(v) {
+			r = gopt.Of[T](v)
+			return false
+		}
+		return true
+	})
+	return r
+}
+
+// Limit creates a new stream whose number of elements is limited to the value provided
+//
+// if the maximum size is greater than the length of this stream, all elements are returned
+func (s *persistentStream[T]) Limit(maxSize int) Stream[T] {
+	max := absZero(maxSize)
+	if l := s.root.len(); l < max {
+		max = l
+	}
+	return &stream[T]{
+		elements: s.root.appendRange(make([]T, 0, max), 0, max),
+	}
+}
+
+// Max returns the maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *persistentStream[T]) Max(c Comparator[T]) *gopt.Optional[T] {
+	r := gopt.Empty[T]()
+	if c != nil {
+		s.root.each(func(v T) bool {
+			if !r.IsPresent() || c.Compare(v, r.Default(v)) > 0 {
+				r = gopt.Of(v)
+			}
+			return true
+		})
+	}
+	return r
+}
+
+// Min returns the minimum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *persistentStream[T]) Min(c Comparator[T]) *gopt.Optional[T] {
+	r := gopt.Empty[T]()
+	if c != nil {
+		s.root.each(func(v T) bool {
+			if !r.IsPresent() || c.Compare(v, r.Default(v)) < 0 {
+				r = gopt.Of(v)
+			}
+			return true
+		})
+	}
+	return r
+}
+
+// MinMax returns the minimum and maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned for both
+func (s *persistentStream[T]) MinMax(c Comparator[T]) (*gopt.Optional[T], *gopt.Optional[T]) {
+	mn, mx := gopt.Empty[T](), gopt.Empty[T]()
+	if c != nil {
+		s.root.each(func(v T) bool {
+			if !mn.IsPresent() {
+				mn, mx = gopt.Of(v), gopt.Of(v)
+			} else if c.Compare(v, mn.Default(v)) < 0 {
+				mn = gopt.Of(v)
+			} else if c.Compare(v, mx.Default(v)) > 0 {
+				mx = gopt.Of(v)
+			}
+			return true
+		})
+	}
+	return mn, mx
+}
+
+// NoneMatch returns whether none of the elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns true
+func (s *persistentStream[T]) NoneMatch(p Predicate[T]) bool {
+	return p == nil || s.root.each(func(v T) bool {
+		return !p.Test(v)
+	})
+}
+
+// NthMatch returns an optional of the nth matching element (1 based) according to the provided predicate
+//
+// if the nth argument is negative, the nth is taken as relative to the last
+//
+// if the provided predicate is nil, any element is taken as matching
+//
+// if no elements match in the specified position, an empty (not present) optional is returned
+func (s *persistentStream[T]) NthMatch(p Predicate[T], nth int) *gopt.Optional[T] {
+	absn := absInt(nth)
+	if l := s.root.len(); absn > l || nth == 0 {
+		return gopt.Empty[T]()
+	} else if p == nil && nth < 0 {
+		return gopt.Of[T](s.root.get(l - absn))
+	} else if p == nil {
+		return gopt.Of[T](s.root.get(nth - 1))
+	}
+	r := gopt.Empty[T]()
+	c := 0
+	match := func(v T) bool {
+		if p.Test(v) {
+			c++
+			if c == absn {
+				r = gopt.Of[T](v)
+				return false
+			}
+		}
+		return true
+	}
+	if nth < 0 {
+		s.root.eachReverse(match)
+	} else {
+		s.root.each(match)
+	}
+	return r
+}
+
+// Page returns the nth page (1 based) of elements of this stream, where each page has the specified size - along with
+// page metadata (total elements, total pages and whether there are next/previous pages)
+//
+// if the page number is out of range or the size is less than 1, the page elements are empty
+func (s *persistentStream[T]) Page(n int, size int) Page[T] {
+	return newRangePage(s.root.len(), n, size, s.rangeOf)
+}
+
+// Paginate returns an iterator over the pages of this stream, where each page has the specified page size
+//
+// if the page size is less than 1, there are no pages
+func (s *persistentStream[T]) Paginate(pageSize int) Pages[T] {
+	return newRangePages(s.root.len(), pageSize, s.rangeOf)
+}
+
+// Peek creates a new stream with the same elements as this stream, additionally performing the provided action on each element
+//
+// Peek is intended for debugging - for example, to see elements as they flow through a chain of operations
+//
+// any error returned by the consumer is ignored, and if the provided consumer is nil, nothing is performed
+func (s *persistentStream[T]) Peek(c Consumer[T]) Stream[T] {
+	return s.flat().Peek(c)
+}
+
+// Reverse creates a new stream composed of elements from this stream but in reverse order
+func (s *persistentStream[T]) Reverse() Stream[T] {
+	return s.flat().Reverse()
+}
+
+// Skip creates a new stream consisting of this stream after discarding the first n elements
+//
+// if the specified n to skip is equal to or greater than the number of elements in this stream,
+// an empty stream is returned
+func (s *persistentStream[T]) Skip(n int) Stream[T] {
+	l := s.root.len()
+	skip := absZero(n)
+	if skip >= l {
+		skip = l
+	}
+	return &stream[T]{
+		elements: s.rangeOf(skip, l),
+	}
+}
+
+// Slice creates a new stream composed of elements from this stream starting at the specified start and including
+// the specified count (or to the end)
+//
+// the start is zero based (and less than zero is ignored)
+//
+// if the specified count is negative, items are selected from the start and then backwards by the count
+func (s *persistentStream[T]) Slice(start int, count int) Stream[T] {
+	start = absZero(start)
+	end := start + count
+	if count < 0 {
+		start, end = end, start
+	}
+	if start < 0 {
+		start = 0
+	}
+	if l := s.root.len(); end > l {
+		end = l
+	}
+	if end < start {
+		end = start
+	}
+	return &stream[T]{
+		elements: s.rangeOf(start, end),
+	}
+}
+
+// Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
+//
+// if the provided comparator is nil, the elements are not sorted
+func (s *persistentStream[T]) Sorted(c Comparator[T]) SortedStream[T] {
+	return s.flat().Sorted(c)
+}
+
+// SymmetricDifference creates a new stream that is the set symmetric difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *persistentStream[T]) SymmetricDifference(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.flat().SymmetricDifference(other, c)
+}
+
+// Union creates a new stream that is the set union of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *persistentStream[T]) Union(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.flat().Union(other, c)
+}
+
+// Unique creates a new stream of unique elements in this stream
+//
+// uniqueness is determined using the provided comparator
+//
+// if provided comparator is nil, Distinct is used as the result
+func (s *persistentStream[T]) Unique(c Comparator[T]) Stream[T] {
+	return s.flat().Unique(c)
+}
+
+// persistentNode is an immutable node of a size-annotated AVL tree - where elements are ordered by position (in-order)
+//
+// nodes are never changed once created, all operations path-copy
+type persistentNode[T any] struct {
+	left   *persistentNode[T]
+	right  *persistentNode[T]
+	value  T
+	size   int
+	height int
+}
+
+func newPersistentNode[T any](left *persistentNode[T], value T, right *persistentNode[T]) *persistentNode[T] {
+	h := left.ht()
+	if rh := right.ht(); rh > h {
+		h = rh
+	}
+	return &persistentNode[T]{
+		left:   left,
+		right:  right,
+		value:  value,
+		size:   left.len() + right.len() + 1,
+		height: h + 1,
+	}
+}
+
+// buildPersistentNode builds a perfectly balanced tree from the values
+func buildPersistentNode[T any](values []T) *persistentNode[T] {
+	if len(values) == 0 {
+		return nil
+	}
+	mid := len(values) / 2
+	return newPersistentNode(buildPersistentNode(values[:mid]), values[mid], buildPersistentNode(values[mid+1:]))
+}
+
+func (n *persistentNode[T]) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *persistentNode[T]) ht() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *persistentNode[T]) get(i int) T {
+	for {
+		if l := n.left.len(); i < l {
+			n = n.left
+		} else if i == l {
+			return n.value
+		} else {
+			i -= l + 1
+			n = n.right
+		}
+	}
+}
+
+func (n *persistentNode[T]) set(i int, v T) *persistentNode[T] {
+	if l := n.left.len(); i < l {
+		return newPersistentNode(n.left.set(i, v), n.value, n.right)
+	} else if i == l {
+		return newPersistentNode(n.left, v, n.right)
+	} else {
+		return newPersistentNode(n.left, n.value, n.right.set(i-l-1, v))
+	}
+}
+
+func (n *persistentNode[T]) appendTo(buf []T) []T {
+	if n != nil {
+		buf = n.left.appendTo(buf)
+		buf = append(buf, n.value)
+		buf = n.right.appendTo(buf)
+	}
+	return buf
+}
+
+// appendRange appends the elements from start (inclusive) to end (exclusive) in order - only visiting the nodes in the range
+func (n *persistentNode[T]) appendRange(buf []T, start, end int) []T {
+	if n == nil || start >= end {
+		return buf
+	}
+	l := n.left.len()
+	if start < l {
+		buf = n.left.appendRange(buf, start, end)
+	}
+	if start <= l && l < end {
+		buf = append(buf, n.value)
+	}
+	if end > l+1 {
+		buf = n.right.appendRange(buf, start-l-1, end-l-1)
+	}
+	return buf
+}
+
+// each calls the function with each element in order - stopping (and returning false) if the function returns false
+func (n *persistentNode[T]) each(f func(v T) bool) bool {
+	return n == nil || (n.left.each(f) && f(n.value) && n.right.each(f))
+}
+
+// eachReverse calls the function with each element in reverse order - stopping (and returning false) if the function returns false
+func (n *persistentNode[T]) eachReverse(f func(v T) bool) bool {
+	return n == nil || (n.right.eachReverse(f) && f(n.value) && n.left.eachReverse(f))
+}
+
+// iterator returns an iterator (pull) function over the elements in order
+func (n *persistentNode[T]) iterator() func() (T, bool) {
+	stack := make([]*persistentNode[T], 0, n.ht())
+	push := func(n *persistentNode[T]) {
+		for ; n != nil; n = n.left {
+			stack = append(stack, n)
+		}
+	}
+	push(n)
+	return func() (T, bool) {
+		var r T
+		if len(stack) == 0 {
+			return r, false
+		}
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		push(top.right)
+		return top.value, true
+	}
+}
+
+// removeLast returns the tree without its last element - and the last element
+func (n *persistentNode[T]) removeLast() (*persistentNode[T], T) {
+	if n.right == nil {
+		return n.left, n.value
+	}
+	r, v := n.right.removeLast()
+	return balancePersistentNode(n.left, n.value, r), v
+}
+
+// balancePersistentNode creates a node from left, value and right - rotating where the heights of left and right differ by two
+func balancePersistentNode[T any](left *persistentNode[T], value T, right *persistentNode[T]) *persistentNode[T] {
+	if lh, rh := left.ht(), right.ht(); lh > rh+1 {
+		if left.left.ht() >= left.right.ht() {
+			return newPersistentNode(left.left, left.value, newPersistentNode(left.right, value, right))
+		}
+		lr := left.right
+		return newPersistentNode(newPersistentNode(left.left, left.value, lr.left), lr.value, newPersistentNode(lr.right, value, right))
+	} else if rh > lh+1 {
+		if right.right.ht() >= right.left.ht() {
+			return newPersistentNode(newPersistentNode(left, value, right.left), right.value, right.right)
+		}
+		rl := right.left
+		return newPersistentNode(newPersistentNode(left, value, rl.left), rl.value, newPersistentNode(rl.right, right.value, right.right))
+	}
+	return newPersistentNode(left, value, right)
+}
+
+// joinPersistentNodes joins two trees of any heights with a value between them
+func joinPersistentNodes[T any](left *persistentNode[T], value T, right *persistentNode[T]) *persistentNode[T] {
+	if left.ht() > right.ht()+1 {
+		return balancePersistentNode(left.left, left.value, joinPersistentNodes(left.right, value, right))
+	} else if right.ht() > left.ht()+1 {
+		return balancePersistentNode(joinPersistentNodes(left, value, right.left), right.value, right.right)
+	}
+	return newPersistentNode(left, value, right)
+}
+
+func concatPersistentNodes[T any](left *persistentNode[T], right *persistentNode[T]) *persistentNode[T] {
+	if left == nil {
+		return right
+	} else if right == nil {
+		return left
+	}
+	l, v := left.removeLast()
+	return joinPersistentNodes(l, v, right)
+}
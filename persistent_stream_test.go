@@ -0,0 +1,263 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewPersistentStream(t *testing.T) {
+	values := []string{"a", "b", "c"}
+	s := NewPersistentStream(values...)
+	require.Equal(t, 3, s.Len())
+	values[0] = "z"
+	require.Equal(t, []string{"a", "b", "c"}, s.AsSlice())
+	s = NewPersistentStream[string]()
+	require.Equal(t, 0, s.Len())
+	require.Equal(t, []string{}, s.AsSlice())
+}
+
+func TestPersistentStream_AsSlice(t *testing.T) {
+	s := NewPersistentStream("a", "b", "c")
+	sl := s.AsSlice()
+	sl[0] = "z"
+	require.Equal(t, []string{"a", "b", "c"}, s.AsSlice())
+}
+
+func TestPersistentStream_Get(t *testing.T) {
+	s := NewPersistentStream("a", "b", "c")
+	for i, v := range []string{"a", "b", "c"} {
+		o := s.Get(i)
+		require.True(t, o.IsPresent())
+		gv, _ := o.GetOk()
+		require.Equal(t, v, gv)
+	}
+	require.False(t, s.Get(-1).IsPresent())
+	require.False(t, s.Get(3).IsPresent())
+}
+
+func TestPersistentStream_Append(t *testing.T) {
+	s := NewPersistentStream("a", "b")
+	s1 := s.Append("c")
+	s2 := s.Append("d", "e")
+	_, ok := s1.(PersistentStream[string])
+	require.True(t, ok)
+	require.Equal(t, []string{"a", "b"}, s.AsSlice())
+	require.Equal(t, []string{"a", "b", "c"}, s1.AsSlice())
+	require.Equal(t, []string{"a", "b", "d", "e"}, s2.AsSlice())
+	require.Equal(t, []string{"a", "b"}, s.Append().AsSlice())
+	require.Equal(t, []string{"x"}, NewPersistentStream[string]().Append("x").AsSlice())
+}
+
+func TestPersistentStream_Prepend(t *testing.T) {
+	s := NewPersistentStream("c", "d")
+	s1 := s.Prepend("a", "b")
+	require.Equal(t, []string{"c", "d"}, s.AsSlice())
+	require.Equal(t, []string{"a", "b", "c", "d"}, s1.AsSlice())
+	require.Equal(t, []string{"z", "a", "b", "c", "d"}, s1.Prepend("z").AsSlice())
+}
+
+func TestPersistentStream_Set(t *testing.T) {
+	s := NewPersistentStream("a", "b", "c")
+	s1 := s.Set(1, "B")
+	require.Equal(t, []string{"a", "b", "c"}, s.AsSlice())
+	require.Equal(t, []string{"a", "B", "c"}, s1.AsSlice())
+	require.Equal(t, s, s.Set(-1, "x"))
+	require.Equal(t, s, s.Set(3, "x"))
+}
+
+func TestPersistentStream_Concat(t *testing.T) {
+	s := NewPersistentStream("a", "b")
+	s1 := s.Concat(NewPersistentStream("c", "d"))
+	_, ok := s1.(PersistentStream[string])
+	require.True(t, ok)
+	require.Equal(t, []string{"a", "b", "c", "d"}, s1.AsSlice())
+	s2 := s.Concat(Of("x", "y"))
+	_, ok = s2.(PersistentStream[string])
+	require.True(t, ok)
+	require.Equal(t, []string{"a", "b", "x", "y"}, s2.AsSlice())
+	require.Equal(t, []string{"a", "b"}, s.Concat(nil).AsSlice())
+	require.Equal(t, []string{"a", "b"}, s.AsSlice())
+}
+
+func TestPersistentStream_Balanced(t *testing.T) {
+	s := NewPersistentStream[int]()
+	expect := make([]int, 0)
+	for i := 0; i < 500; i++ {
+		if i%2 == 0 {
+			s = s.Append(i).(PersistentStream[int])
+			expect = append(expect, i)
+		} else {
+			s = s.Prepend(i)
+			expect = append([]int{i}, expect...)
+		}
+	}
+	require.Equal(t, expect, s.AsSlice())
+	for i := 0; i < 500; i += 7 {
+		s = s.Set(i, -i)
+		expect[i] = -i
+	}
+	for i, v := range expect {
+		gv, _ := s.Get(i).GetOk()
+		require.Equal(t, v, gv)
+	}
+	s = s.Concat(s).(PersistentStream[int])
+	require.Equal(t, append(append([]int{}, expect...), expect...), s.AsSlice())
+	root := s.(*persistentStream[int]).root
+	// height of an AVL tree is at most ~1.44 log2(n)...
+	require.LessOrEqual(t, root.height, 15)
+	checkPersistentNodeBalanced(t, root)
+}
+
+func checkPersistentNodeBalanced[T any](t *testing.T, n *persistentNode[T]) {
+	if n != nil {
+		d := n.left.ht() - n.right.ht()
+		require.True(t, d >= -1 && d <= 1)
+		require.Equal(t, n.left.len()+n.right.len()+1, n.size)
+		checkPersistentNodeBalanced(t, n.left)
+		checkPersistentNodeBalanced(t, n.right)
+	}
+}
+
+func TestPersistentStream_ConcurrentReads(t *testing.T) {
+	s := NewPersistentStream("a", "b", "c")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s2 := s.Append(strings.Repeat("x", i)).(PersistentStream[string]).Set(0, "A")
+			require.Equal(t, 4, s2.Len())
+			require.Equal(t, 3, s.Len())
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, []string{"a", "b", "c"}, s.AsSlice())
+}
+
+func TestPersistentStream_ReadOperations(t *testing.T) {
+	s := NewPersistentStream("c", "a", "b", "a")
+	upper := NewPredicate(func(v string) bool {
+		return strings.ToUpper(v) == v
+	})
+	isA := NewPredicate(func(v string) bool {
+		return v == "a"
+	})
+	require.False(t, s.AllMatch(upper))
+	require.False(t, s.AnyMatch(upper))
+	require.True(t, s.NoneMatch(upper))
+	require.Equal(t, 2, s.Count(isA))
+	require.Equal(t, []string{"c", "b"}, s.Difference(Of("a"), StringComparator).AsSlice())
+	require.Equal(t, []string{"c", "a", "b"}, s.Distinct().AsSlice())
+	require.Equal(t, []string{"a", "a"}, s.Filter(isA).AsSlice())
+	require.Equal(t, "c", s.FirstMatch(nil).OrElse(""))
+	count := 0
+	require.NoError(t, s.ForEach(NewConsumer(func(v string) error {
+		count++
+		return nil
+	})))
+	require.Equal(t, 4, count)
+	require.True(t, s.Has("b", StringComparator))
+	require.Equal(t, []string{"a", "a"}, s.Intersection(Of("a"), StringComparator).AsSlice())
+	next := s.Iterator(isA)
+	v, ok := next()
+	require.True(t, ok)
+	require.Equal(t, "a", v)
+	require.Equal(t, "a", s.LastMatch(nil).OrElse(""))
+	require.Equal(t, 2, s.Limit(2).Len())
+	require.Equal(t, "c", s.Max(StringComparator).OrElse(""))
+	require.Equal(t, "a", s.Min(StringComparator).OrElse(""))
+	mn, mx := s.MinMax(StringComparator)
+	require.Equal(t, "a", mn.OrElse(""))
+	require.Equal(t, "c", mx.OrElse(""))
+	require.Equal(t, "b", s.NthMatch(nil, 3).OrElse(""))
+	require.Equal(t, []string{"b", "a"}, s.Page(2, 2).Elements.AsSlice())
+	require.Equal(t, 2, s.Paginate(3).Len())
+	require.Equal(t, 4, s.Peek(nil).Len())
+	require.Equal(t, []string{"a", "b", "a", "c"}, s.Reverse().AsSlice())
+	require.Equal(t, []string{"b", "a"}, s.Skip(2).AsSlice())
+	require.Equal(t, []string{"a", "b"}, s.Slice(1, 2).AsSlice())
+	require.Equal(t, []string{"a", "a", "b", "c"}, s.Sorted(StringComparator).AsSlice())
+	require.Equal(t, []string{"c", "b", "z"}, s.SymmetricDifference(Of("a", "z"), StringComparator).AsSlice())
+	require.Equal(t, 5, s.Union(Of("z"), StringComparator).Len())
+	require.Equal(t, 3, s.Unique(StringComparator).Len())
+	// derived streams never alias the persistent stream...
+	l := s.Limit(2)
+	l2 := l.Append("z")
+	require.Equal(t, []string{"c", "a", "z"}, l2.AsSlice())
+	require.Equal(t, []string{"c", "a", "b", "a"}, s.AsSlice())
+}
+
+func TestPersistentStream_TreeWalksMatchStream(t *testing.T) {
+	// build an irregular tree (by appending/prepending) and compare the in-place tree walks with a flat stream...
+	ps := NewPersistentStream[int]()
+	for i := 0; i < 50; i++ {
+		if i%3 == 0 {
+			ps = ps.Prepend(i)
+		} else {
+			ps = ps.Append(i, i*7%11).(PersistentStream[int])
+		}
+	}
+	fs := Of(ps.AsSlice()...)
+	even := NewPredicate(func(v int) bool {
+		return v%2 == 0
+	})
+	none := NewPredicate(func(v int) bool {
+		return v < 0
+	})
+	for _, p := range []Predicate[int]{nil, even, none} {
+		require.Equal(t, fs.AllMatch(p), ps.AllMatch(p))
+		require.Equal(t, fs.AnyMatch(p), ps.AnyMatch(p))
+		require.Equal(t, fs.NoneMatch(p), ps.NoneMatch(p))
+		require.Equal(t, fs.Count(p), ps.Count(p))
+		require.Equal(t, fs.FirstMatch(p).OrElse(-1), ps.FirstMatch(p).OrElse(-1))
+		require.Equal(t, fs.LastMatch(p).OrElse(-1), ps.LastMatch(p).OrElse(-1))
+		for nth := -90; nth <= 90; nth++ {
+			require.Equal(t, fs.NthMatch(p, nth).OrElse(-1), ps.NthMatch(p, nth).OrElse(-1), nth)
+		}
+	}
+	collect := func(next func() (int, bool)) []int {
+		r := make([]int, 0)
+		for v, ok := next(); ok; v, ok = next() {
+			r = append(r, v)
+		}
+		return r
+	}
+	require.Equal(t, collect(fs.Iterator()), collect(ps.Iterator()))
+	require.Equal(t, collect(fs.Iterator(even)), collect(ps.Iterator(even)))
+	require.Equal(t, []int{}, collect(ps.Iterator(none)))
+	require.Equal(t, []int{}, collect(NewPersistentStream[int]().Iterator()))
+	require.Equal(t, fs.Max(IntComparator).OrElse(-1), ps.Max(IntComparator).OrElse(-1))
+	require.Equal(t, fs.Min(IntComparator).OrElse(-1), ps.Min(IntComparator).OrElse(-1))
+	require.False(t, ps.Max(nil).IsPresent())
+	mn, mx := ps.MinMax(IntComparator)
+	fmn, fmx := fs.MinMax(IntComparator)
+	require.Equal(t, fmn.OrElse(-1), mn.OrElse(-1))
+	require.Equal(t, fmx.OrElse(-1), mx.OrElse(-1))
+	require.True(t, ps.Has(42, IntComparator))
+	require.False(t, ps.Has(-1, IntComparator))
+	require.False(t, ps.Has(42, nil))
+	for n := -1; n <= 100; n++ {
+		require.Equal(t, fs.Limit(n).AsSlice(), ps.Limit(n).AsSlice(), n)
+		require.Equal(t, fs.Skip(n).AsSlice(), ps.Skip(n).AsSlice(), n)
+		if n <= fs.Len() {
+			require.Equal(t, fs.Slice(n, 7).AsSlice(), ps.Slice(n, 7).AsSlice(), n)
+			require.Equal(t, fs.Slice(n, -7).AsSlice(), ps.Slice(n, -7).AsSlice(), n)
+		}
+		require.Equal(t, fs.Page(n, 7), ps.Page(n, 7), n)
+	}
+	fp, pp := fs.Paginate(9), ps.Paginate(9)
+	require.Equal(t, fp.Len(), pp.Len())
+	for p, ok := pp.Next(); ok; p, ok = pp.Next() {
+		fpg, _ := fp.Next()
+		require.Equal(t, fpg, p)
+	}
+	sum := 0
+	require.NoError(t, ps.ForEach(NewConsumer(func(v int) error {
+		sum += v
+		return nil
+	})))
+	require.Equal(t, fs.Count(nil), len(ps.AsSlice()))
+	require.Greater(t, sum, 0)
+}
@@ -0,0 +1,140 @@
+package streams
+
+// AllOf creates a new Predicate that represents a short-circuiting logical AND of all the provided predicates
+//
+// nil predicates are skipped - and if there are no (non-nil) predicates, the predicate always returns true
+//
+// where any of the provided predicates is itself an AllOf predicate, its predicates are flattened into the new predicate
+// (so that evaluation is a single loop)
+func AllOf[T any](ps ...Predicate[T]) Predicate[T] {
+	return newMultiPredicate[T](allOf, ps, allOf)
+}
+
+// AnyOf creates a new Predicate that represents a short-circuiting logical OR of all the provided predicates
+//
+// nil predicates are skipped - and if there are no (non-nil) predicates, the predicate always returns false
+//
+// where any of the provided predicates is itself an AnyOf predicate, its predicates are flattened into the new predicate
+// (so that evaluation is a single loop)
+func AnyOf[T any](ps ...Predicate[T]) Predicate[T] {
+	return newMultiPredicate[T](anyOf, ps, anyOf)
+}
+
+// NoneOf creates a new Predicate that represents a short-circuiting logical NOR of all the provided predicates - i.e. none of the
+// predicates are true
+//
+// nil predicates are skipped - and if there are no (non-nil) predicates, the predicate always returns true
+//
+// where any of the provided predicates is an AnyOf predicate, its predicates are flattened into the new predicate
+// (so that evaluation is a single loop)
+func NoneOf[T any](ps ...Predicate[T]) Predicate[T] {
+	return newMultiPredicate[T](noneOf, ps, anyOf)
+}
+
+// ExactlyOneOf creates a new Predicate that tests whether exactly one of the provided predicates is true
+//
+// evaluation short-circuits once a second true predicate is found
+//
+// nil predicates are skipped - and if there are no (non-nil) predicates, the predicate always returns false
+func ExactlyOneOf[T any](ps ...Predicate[T]) Predicate[T] {
+	return newMultiPredicate[T](exactlyOneOf, ps, -1)
+}
+
+// XOr creates a new Predicate that represents a logical exclusive OR of the two provided predicates
+//
+// nil predicates are skipped (i.e. XOr of a predicate and nil is the same as the predicate)
+func XOr[T any](p1, p2 Predicate[T]) Predicate[T] {
+	return ExactlyOneOf[T](p1, p2)
+}
+
+type multiPredicateOp int
+
+const (
+	allOf multiPredicateOp = iota
+	anyOf
+	noneOf
+	exactlyOneOf
+)
+
+type multiPredicate[T any] struct {
+	op multiPredicateOp
+	ps []Predicate[T]
+}
+
+// newMultiPredicate creates a multi predicate - skipping nil predicates and flattening predicates that are multi predicates
+// with the flatten op
+func newMultiPredicate[T any](op multiPredicateOp, ps []Predicate[T], flatten multiPredicateOp) *multiPredicate[T] {
+	r := &multiPredicate[T]{
+		op: op,
+		ps: make([]Predicate[T], 0, len(ps)),
+	}
+	for _, p := range ps {
+		if mp, ok := p.(*multiPredicate[T]); ok && mp.op == flatten {
+			r.ps = append(r.ps, mp.ps...)
+		} else if p != nil {
+			r.ps = append(r.ps, p)
+		}
+	}
+	return r
+}
+
+// Test evaluates this predicate against the supplied value
+func (p *multiPredicate[T]) Test(v T) bool {
+	switch p.op {
+	case allOf:
+		for _, ip := range p.ps {
+			if !ip.Test(v) {
+				return false
+			}
+		}
+		return true
+	case anyOf:
+		for _, ip := range p.ps {
+			if ip.Test(v) {
+				return true
+			}
+		}
+		return false
+	case noneOf:
+		for _, ip := range p.ps {
+			if ip.Test(v) {
+				return false
+			}
+		}
+		return true
+	}
+	found := false
+	for _, ip := range p.ps {
+		if ip.Test(v) {
+			if found {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// And creates a composed predicate that represents a short-circuiting logical AND of this predicate and another
+func (p *multiPredicate[T]) And(other Predicate[T]) Predicate[T] {
+	return AllOf[T](p, other)
+}
+
+// Or creates a composed predicate that represents a short-circuiting logical OR of this predicate and another
+func (p *multiPredicate[T]) Or(other Predicate[T]) Predicate[T] {
+	return AnyOf[T](p, other)
+}
+
+// Negate creates a composed predicate that represents a logical NOT of this predicate
+func (p *multiPredicate[T]) Negate() Predicate[T] {
+	switch p.op {
+	case anyOf:
+		return &multiPredicate[T]{op: noneOf, ps: p.ps}
+	case noneOf:
+		return &multiPredicate[T]{op: anyOf, ps: p.ps}
+	}
+	return predicate[T]{
+		inner:   p,
+		negated: true,
+	}
+}
@@ -0,0 +1,102 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func countingPredicate(result bool, calls *int) Predicate[int] {
+	return NewPredicate(func(v int) bool {
+		*calls++
+		return result
+	})
+}
+
+func TestAllOf(t *testing.T) {
+	p := AllOf(Between(1, 10, IntComparator), nil, NewPredicate(func(v int) bool {
+		return v%2 == 0
+	}))
+	require.True(t, p.Test(2))
+	require.False(t, p.Test(3))
+	require.False(t, p.Test(12))
+	require.True(t, AllOf[int]().Test(1))
+	require.True(t, AllOf[int](nil, nil).Test(1))
+
+	calls := 0
+	p = AllOf(countingPredicate(false, &calls), countingPredicate(true, &calls))
+	require.False(t, p.Test(1))
+	require.Equal(t, 1, calls)
+}
+
+func TestAnyOf(t *testing.T) {
+	p := AnyOf(Eq(1), nil, Eq(3))
+	require.True(t, p.Test(1))
+	require.False(t, p.Test(2))
+	require.True(t, p.Test(3))
+	require.False(t, AnyOf[int]().Test(1))
+
+	calls := 0
+	p = AnyOf(countingPredicate(true, &calls), countingPredicate(false, &calls))
+	require.True(t, p.Test(1))
+	require.Equal(t, 1, calls)
+}
+
+func TestNoneOf(t *testing.T) {
+	p := NoneOf(Eq(1), nil, Eq(3))
+	require.False(t, p.Test(1))
+	require.True(t, p.Test(2))
+	require.False(t, p.Test(3))
+	require.True(t, NoneOf[int]().Test(1))
+}
+
+func TestExactlyOneOf(t *testing.T) {
+	p := ExactlyOneOf(Between(1, 5, IntComparator), nil, Between(3, 8, IntComparator))
+	require.True(t, p.Test(1))
+	require.False(t, p.Test(4))
+	require.True(t, p.Test(7))
+	require.False(t, p.Test(9))
+	require.False(t, ExactlyOneOf[int]().Test(1))
+
+	calls := 0
+	p = ExactlyOneOf(countingPredicate(true, &calls), countingPredicate(true, &calls), countingPredicate(true, &calls))
+	require.False(t, p.Test(1))
+	require.Equal(t, 2, calls)
+}
+
+func TestXOr(t *testing.T) {
+	p := XOr(Eq(1), Between(0, 2, IntComparator))
+	require.False(t, p.Test(1))
+	require.True(t, p.Test(0))
+	require.False(t, p.Test(3))
+	require.True(t, XOr(Eq(1), nil).Test(1))
+}
+
+func TestMultiPredicate_Flattens(t *testing.T) {
+	p := AllOf(AllOf(Eq(1), Eq(1)), Eq(1), nil)
+	require.Equal(t, 3, len(p.(*multiPredicate[int]).ps))
+	p = AnyOf(Eq(1), AnyOf(Eq(2), Eq(3))).Or(Eq(4))
+	require.Equal(t, 4, len(p.(*multiPredicate[int]).ps))
+	require.True(t, p.Test(4))
+	p = NoneOf(Eq(1), AnyOf(Eq(2), Eq(3)))
+	require.Equal(t, 3, len(p.(*multiPredicate[int]).ps))
+	p = AllOf(Eq(1), AnyOf(Eq(2), Eq(3))).And(Eq(4))
+	require.Equal(t, 3, len(p.(*multiPredicate[int]).ps))
+	p = ExactlyOneOf(Eq(1), ExactlyOneOf(Eq(2), Eq(3)))
+	require.Equal(t, 2, len(p.(*multiPredicate[int]).ps))
+}
+
+func TestMultiPredicate_Negate(t *testing.T) {
+	p := AnyOf(Eq(1), Eq(2)).Negate()
+	require.Equal(t, noneOf, p.(*multiPredicate[int]).op)
+	require.False(t, p.Test(1))
+	require.True(t, p.Test(3))
+	p = p.Negate()
+	require.Equal(t, anyOf, p.(*multiPredicate[int]).op)
+	require.True(t, p.Test(1))
+	p = AllOf(Eq(1), Between(0, 2, IntComparator)).Negate()
+	require.False(t, p.Test(1))
+	require.True(t, p.Test(2))
+	p = ExactlyOneOf(Eq(1), Eq(2)).Negate()
+	require.False(t, p.Test(1))
+	require.True(t, p.Test(3))
+}
@@ -0,0 +1,115 @@
+package streams
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Eq creates a new Predicate that tests whether values are equal to the specified value
+func Eq[T comparable](v T) Predicate[T] {
+	return NewPredicate(func(tv T) bool {
+		return tv == v
+	})
+}
+
+// Between creates a new Predicate that tests whether values are between the specified lo and hi (inclusive)
+//
+// comparison of values is determined using the provided comparator (if the provided comparator is nil, the predicate always returns false)
+func Between[T any](lo, hi T, c Comparator[T]) Predicate[T] {
+	return NewPredicate(func(v T) bool {
+		return c != nil && c.Compare(v, lo) >= 0 && c.Compare(v, hi) <= 0
+	})
+}
+
+// In creates a new Predicate that tests whether values are one of the specified values
+func In[T comparable](values ...T) Predicate[T] {
+	set := make(map[T]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return NewPredicate(func(v T) bool {
+		return set[v]
+	})
+}
+
+// NotIn creates a new Predicate that tests whether values are not any of the specified values
+func NotIn[T comparable](values ...T) Predicate[T] {
+	return In[T](values...).Negate()
+}
+
+// IsZero creates a new Predicate that tests whether values are the zero value for their type
+func IsZero[T any]() Predicate[T] {
+	return NewPredicate(func(v T) bool {
+		rv := reflect.ValueOf(v)
+		return !rv.IsValid() || rv.IsZero()
+	})
+}
+
+// IsNil creates a new Predicate that tests whether values are nil
+//
+// only values of pointer, interface, slice, map, chan or func types can be nil (for values of any other type, the predicate
+// always returns false)
+func IsNil[T any]() Predicate[T] {
+	return NewPredicate(func(v T) bool {
+		rv := reflect.ValueOf(v)
+		if !rv.IsValid() {
+			return true
+		}
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+			return rv.IsNil()
+		}
+		return false
+	})
+}
+
+// HasPrefix creates a new Predicate that tests whether strings begin with the specified prefix
+func HasPrefix(prefix string) Predicate[string] {
+	return NewPredicate(func(v string) bool {
+		return strings.HasPrefix(v, prefix)
+	})
+}
+
+// HasSuffix creates a new Predicate that tests whether strings end with the specified suffix
+func HasSuffix(suffix string) Predicate[string] {
+	return NewPredicate(func(v string) bool {
+		return strings.HasSuffix(v, suffix)
+	})
+}
+
+// Contains creates a new Predicate that tests whether strings contain the specified substring
+func Contains(substr string) Predicate[string] {
+	return NewPredicate(func(v string) bool {
+		return strings.Contains(v, substr)
+	})
+}
+
+// MatchesRegexp creates a new Predicate that tests whether strings match the specified regular expression
+//
+// if the specified regular expression is nil, the predicate always returns false
+func MatchesRegexp(re *regexp.Regexp) Predicate[string] {
+	return NewPredicate(func(v string) bool {
+		return re != nil && re.MatchString(v)
+	})
+}
+
+// EqualFold creates a new Predicate that tests whether strings are equal to the specified string, under Unicode case-folding
+// (i.e. case insensitive)
+func EqualFold(s string) Predicate[string] {
+	return NewPredicate(func(v string) bool {
+		return strings.EqualFold(v, s)
+	})
+}
+
+// By creates a new Predicate that tests values by applying the provided predicate to the key extracted from each value
+//
+// for example, to lift a predicate onto a struct field
+//  adults := By(func(p Person) int { return p.Age }, Between(18, 150, IntComparator))
+//
+// if the provided key extractor or predicate is nil, the predicate always returns false
+func By[T any, K any](key func(v T) K, p Predicate[K]) Predicate[T] {
+	return NewPredicate(func(v T) bool {
+		return key != nil && p != nil && p.Test(key(v))
+	})
+}
@@ -0,0 +1,124 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"testing"
+)
+
+func TestEq(t *testing.T) {
+	p := Eq("a")
+	require.True(t, p.Test("a"))
+	require.False(t, p.Test("b"))
+	require.True(t, p.Negate().Test("b"))
+	require.Equal(t, 2, Of("a", "b", "a").Count(p))
+}
+
+func TestBetween(t *testing.T) {
+	p := Between(2, 4, IntComparator)
+	require.False(t, p.Test(1))
+	require.True(t, p.Test(2))
+	require.True(t, p.Test(3))
+	require.True(t, p.Test(4))
+	require.False(t, p.Test(5))
+	p = Between(2, 4, nil)
+	require.False(t, p.Test(3))
+}
+
+func TestIn(t *testing.T) {
+	p := In("a", "c")
+	require.True(t, p.Test("a"))
+	require.False(t, p.Test("b"))
+	require.True(t, p.Test("c"))
+	require.False(t, In[string]().Test("a"))
+}
+
+func TestNotIn(t *testing.T) {
+	p := NotIn("a", "c")
+	require.False(t, p.Test("a"))
+	require.True(t, p.Test("b"))
+	require.True(t, NotIn[string]().Test("a"))
+}
+
+func TestIsZero(t *testing.T) {
+	require.True(t, IsZero[int]().Test(0))
+	require.False(t, IsZero[int]().Test(1))
+	require.True(t, IsZero[string]().Test(""))
+	type st struct {
+		a  string
+		sl []int
+	}
+	require.True(t, IsZero[st]().Test(st{}))
+	require.False(t, IsZero[st]().Test(st{sl: []int{}}))
+	require.True(t, IsZero[any]().Test(nil))
+	require.False(t, IsZero[any]().Test("a"))
+	require.True(t, IsZero[*st]().Test(nil))
+}
+
+func TestIsNil(t *testing.T) {
+	require.True(t, IsNil[*int]().Test(nil))
+	i := 1
+	require.False(t, IsNil[*int]().Test(&i))
+	require.True(t, IsNil[[]int]().Test(nil))
+	require.False(t, IsNil[[]int]().Test([]int{}))
+	require.True(t, IsNil[map[string]int]().Test(nil))
+	require.True(t, IsNil[func()]().Test(nil))
+	require.True(t, IsNil[any]().Test(nil))
+	var ip *int
+	require.True(t, IsNil[any]().Test(ip))
+	require.False(t, IsNil[int]().Test(0))
+	require.False(t, IsNil[string]().Test(""))
+}
+
+func TestHasPrefix(t *testing.T) {
+	p := HasPrefix("ab")
+	require.True(t, p.Test("abc"))
+	require.False(t, p.Test("bc"))
+}
+
+func TestHasSuffix(t *testing.T) {
+	p := HasSuffix("bc")
+	require.True(t, p.Test("abc"))
+	require.False(t, p.Test("ab"))
+}
+
+func TestContains(t *testing.T) {
+	p := Contains("b")
+	require.True(t, p.Test("abc"))
+	require.False(t, p.Test("ac"))
+}
+
+func TestMatchesRegexp(t *testing.T) {
+	p := MatchesRegexp(regexp.MustCompile(`^[a-z]+\d$`))
+	require.True(t, p.Test("abc1"))
+	require.False(t, p.Test("abc"))
+	require.False(t, MatchesRegexp(nil).Test("abc"))
+}
+
+func TestEqualFold(t *testing.T) {
+	p := EqualFold("Go")
+	require.True(t, p.Test("GO"))
+	require.True(t, p.Test("go"))
+	require.False(t, p.Test("goo"))
+}
+
+func TestBy(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	age := func(v person) int {
+		return v.age
+	}
+	name := func(v person) string {
+		return v.name
+	}
+	p := By(age, Between(18, 150, IntComparator))
+	require.True(t, p.Test(person{"Frodo", 50}))
+	require.False(t, p.Test(person{"Pippin", 17}))
+	p = p.And(By(name, HasPrefix("F")))
+	require.True(t, p.Test(person{"Frodo", 50}))
+	require.False(t, p.Test(person{"Sam", 38}))
+	require.False(t, By[person, int](nil, Eq(1)).Test(person{}))
+	require.False(t, By[person, int](age, nil).Test(person{}))
+}
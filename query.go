@@ -0,0 +1,221 @@
+package streams
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Query is a SQL like query over a Stream - built using Select, for example
+//  q := Select(mapper).From(s).Where(p).GroupBy(key).Having(hp).OrderBy(c).Limit(10).Offset(20)
+//  result, err := q.Execute()
+//
+// the clauses of the query can be specified in any order, but are executed in SQL order...
+//   - FROM: the source stream
+//   - WHERE: filters elements of the source (using Stream.Filter)
+//   - GROUP BY: groups the elements by key (in first-encounter order of keys)
+//   - SELECT: maps the elements (or, if grouped, each group) using the Mapper - so when grouped, the Mapper is usually
+//     an aggregating mapper (i.e. yields a single result per group)
+//   - HAVING: filters the selected results (using Stream.Filter)
+//   - ORDER BY: sorts the results (using Stream.Sorted)
+//   - OFFSET: skips results (using Stream.Skip)
+//   - LIMIT: limits the number of results (using Stream.Limit)
+//
+// queries are immutable - each clause method returns a new Query, so a partially built query can be re-used
+type Query[T any, R any] interface {
+	// From returns a new Query with the specified source stream (a nil source yields no results)
+	From(s Stream[T]) Query[T, R]
+	// Where returns a new Query with the specified predicate filtering source elements
+	//
+	// if Where is used more than once, the predicates are logically AND-ed
+	Where(p Predicate[T]) Query[T, R]
+	// GroupBy returns a new Query that groups source elements by the key function
+	//
+	// keys are grouped by equality (and keys that are not comparable - e.g. slices or maps - are compared using reflect.DeepEqual),
+	// keys that are not equal to themselves (e.g. NaN) are all grouped into a single group
+	GroupBy(key func(v T) any) Query[T, R]
+	// Having returns a new Query with the specified predicate filtering the selected results
+	//
+	// if Having is used more than once, the predicates are logically AND-ed
+	Having(p Predicate[R]) Query[T, R]
+	// OrderBy returns a new Query with the results sorted using the specified comparator
+	//
+	// if OrderBy is used more than once, the comparators are chained (i.e. later comparators are used when earlier comparators yield equal)
+	OrderBy(c Comparator[R]) Query[T, R]
+	// Limit returns a new Query that limits the number of results
+	//
+	// if the limit is negative, the number of results is not limited (i.e. any previous limit is removed)
+	Limit(n int) Query[T, R]
+	// Offset returns a new Query that skips the first n results
+	//
+	// if the offset is negative, no results are skipped
+	Offset(n int) Query[T, R]
+	// Execute executes the query and returns the results
+	//
+	// if the Mapper returns an error, that error is returned
+	Execute() (Stream[R], error)
+	// Explain returns a description of the query plan - one line per executed step
+	Explain() string
+}
+
+// Select creates a new Query using the specified Mapper for the selected results
+//
+// Select panics if a nil Mapper is supplied
+func Select[T any, R any](mapper Mapper[T, R]) Query[T, R] {
+	if mapper == nil {
+		panic("mapper cannot be nil")
+	}
+	return query[T, R]{
+		mapper: mapper,
+		limit:  -1,
+	}
+}
+
+type query[T any, R any] struct {
+	mapper  Mapper[T, R]
+	source  Stream[T]
+	where   Predicate[T]
+	groupBy func(v T) any
+	having  Predicate[R]
+	orderBy Comparator[R]
+	limit   int
+	offset  int
+}
+
+func (q query[T, R]) From(s Stream[T]) Query[T, R] {
+	q.source = s
+	return q
+}
+
+func (q query[T, R]) Where(p Predicate[T]) Query[T, R] {
+	if q.where == nil {
+		q.where = p
+	} else if p != nil {
+		q.where = AllOf(q.where, p)
+	}
+	return q
+}
+
+func (q query[T, R]) GroupBy(key func(v T) any) Query[T, R] {
+	q.groupBy = key
+	return q
+}
+
+func (q query[T, R]) Having(p Predicate[R]) Query[T, R] {
+	if q.having == nil {
+		q.having = p
+	} else if p != nil {
+		q.having = AllOf(q.having, p)
+	}
+	return q
+}
+
+func (q query[T, R]) OrderBy(c Comparator[R]) Query[T, R] {
+	if q.orderBy == nil {
+		q.orderBy = c
+	} else if c != nil {
+		q.orderBy = q.orderBy.Then(c)
+	}
+	return q
+}
+
+func (q query[T, R]) Limit(n int) Query[T, R] {
+	q.limit = n
+	if n < 0 {
+		q.limit = -1
+	}
+	return q
+}
+
+func (q query[T, R]) Offset(n int) Query[T, R] {
+	q.offset = absZero(n)
+	return q
+}
+
+func (q query[T, R]) Execute() (Stream[R], error) {
+	if q.source == nil {
+		return Of[R](), nil
+	}
+	s := q.source
+	if q.where != nil {
+		s = s.Filter(q.where)
+	}
+	var r Stream[R]
+	if q.groupBy != nil {
+		results := make([]R, 0)
+		groups := newKeyedGroups[any, T]()
+		for _, v := range elementsOf(s) {
+			groups.add(q.groupBy(v), v)
+		}
+		for _, group := range groups.groups {
+			gr, err := q.mapper.Map(Of(group...))
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, elementsOf(gr)...)
+		}
+		r = Of(results...)
+	} else {
+		var err error
+		if r, err = q.mapper.Map(s); err != nil {
+			return nil, err
+		}
+	}
+	if q.having != nil {
+		r = r.Filter(q.having)
+	}
+	if q.orderBy != nil {
+		r = r.Sorted(q.orderBy)
+	}
+	if q.offset > 0 {
+		r = r.Skip(q.offset)
+	}
+	if q.limit >= 0 {
+		r = r.Limit(q.limit)
+	}
+	return r, nil
+}
+
+func (q query[T, R]) Explain() string {
+	steps := make([]string, 0, 8)
+	add := func(clause string, format string, args ...any) {
+		steps = append(steps, fmt.Sprintf("%d. %-8s ", len(steps)+1, clause)+fmt.Sprintf(format, args...))
+	}
+	if q.source == nil {
+		add("FROM", "<none> (no results)")
+	} else {
+		add("FROM", "%s", describe(q.source))
+	}
+	if q.where != nil {
+		add("WHERE", "Filter(%s)", describe(q.where))
+	}
+	if q.groupBy != nil {
+		add("GROUP BY", "group by key %s (first-encounter order)", describe(q.groupBy))
+		add("SELECT", "Map(%s) per group", describe(q.mapper))
+	} else {
+		add("SELECT", "Map(%s)", describe(q.mapper))
+	}
+	if q.having != nil {
+		add("HAVING", "Filter(%s)", describe(q.having))
+	}
+	if q.orderBy != nil {
+		add("ORDER BY", "Sorted(%s)", describe(q.orderBy))
+	}
+	if q.offset > 0 {
+		add("OFFSET", "Skip(%d)", q.offset)
+	}
+	if q.limit >= 0 {
+		add("LIMIT", "Limit(%d)", q.limit)
+	}
+	return strings.Join(steps, "\n")
+}
+
+var packagePaths = regexp.MustCompile(`(?:[\w.-]+/)+`)
+
+// describe describes a query component - using its String method if it has one, otherwise its type (without package paths)
+func describe(v any) string {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return packagePaths.ReplaceAllString(fmt.Sprintf("%T", v), "")
+}
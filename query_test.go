@@ -0,0 +1,207 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+type querySale struct {
+	region string
+	item   string
+	amount int
+}
+
+type queryTotal struct {
+	region string
+	total  int
+}
+
+type queryTotalMapper struct{}
+
+func (m queryTotalMapper) Map(in Stream[querySale]) (Stream[queryTotal], error) {
+	r := queryTotal{}
+	_ = in.ForEach(NewConsumer(func(v querySale) error {
+		r.region = v.region
+		r.total += v.amount
+		return nil
+	}))
+	return Of(r), nil
+}
+
+var querySales = Of(
+	querySale{"north", "apples", 10},
+	querySale{"south", "pears", 5},
+	querySale{"east", "apples", 7},
+	querySale{"north", "pears", 3},
+	querySale{"west", "plums", 1},
+	querySale{"south", "apples", 20},
+	querySale{"east", "plums", 2},
+)
+
+func TestSelect(t *testing.T) {
+	m := NewMapper(NewConverter(func(v querySale) (string, error) {
+		return v.item, nil
+	}))
+	r, err := Select(m).From(querySales).Execute()
+	require.NoError(t, err)
+	require.Equal(t, []string{"apples", "pears", "apples", "pears", "plums", "apples", "plums"}, r.AsSlice())
+
+	r, err = Select(m).Execute()
+	require.NoError(t, err)
+	require.Equal(t, 0, r.Len())
+
+	require.Panics(t, func() {
+		Select[string, string](nil)
+	})
+}
+
+func TestQuery_WhereOrderByLimitOffset(t *testing.T) {
+	m := NewMapper(NewConverter(func(v querySale) (int, error) {
+		return v.amount, nil
+	}))
+	q := Select(m).From(querySales).
+		Where(NewPredicate(func(v querySale) bool {
+			return v.item != "plums"
+		})).
+		Where(nil).
+		Where(NewPredicate(func(v querySale) bool {
+			return v.region != "west"
+		})).
+		OrderBy(IntComparator.Reversed())
+	r, err := q.Execute()
+	require.NoError(t, err)
+	require.Equal(t, []int{20, 10, 7, 5, 3}, r.AsSlice())
+
+	r, err = q.Offset(1).Limit(2).Execute()
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 7}, r.AsSlice())
+	r, err = q.Limit(0).Execute()
+	require.NoError(t, err)
+	require.Equal(t, 0, r.Len())
+	r, err = q.Offset(10).Execute()
+	require.NoError(t, err)
+	require.Equal(t, 0, r.Len())
+	// negative limit removes the limit, negative offset is no offset...
+	r, err = q.Limit(2).Limit(-3).Execute()
+	require.NoError(t, err)
+	require.Equal(t, []int{20, 10, 7, 5, 3}, r.AsSlice())
+	r, err = q.Offset(-2).Limit(2).Execute()
+	require.NoError(t, err)
+	require.Equal(t, []int{20, 10}, r.AsSlice())
+	require.NotContains(t, q.Limit(-1).Explain(), "LIMIT")
+
+	// original query is unaffected...
+	r, err = q.Execute()
+	require.NoError(t, err)
+	require.Equal(t, 5, r.Len())
+}
+
+func TestQuery_GroupByHaving(t *testing.T) {
+	q := Select[querySale, queryTotal](queryTotalMapper{}).
+		From(querySales).
+		GroupBy(func(v querySale) any {
+			return v.region
+		})
+	r, err := q.Execute()
+	require.NoError(t, err)
+	require.Equal(t, []queryTotal{{"north", 13}, {"south", 25}, {"east", 9}, {"west", 1}}, r.AsSlice())
+
+	byTotal := NewComparator(func(v1, v2 queryTotal) int {
+		return IntComparator.Compare(v1.total, v2.total)
+	})
+	byRegion := NewComparator(func(v1, v2 queryTotal) int {
+		return StringComparator.Compare(v1.region, v2.region)
+	})
+	r, err = q.Where(NewPredicate(func(v querySale) bool {
+		return v.item != "apples"
+	})).Having(NewPredicate(func(v queryTotal) bool {
+		return v.total > 1
+	})).OrderBy(byTotal).OrderBy(byRegion).Execute()
+	require.NoError(t, err)
+	require.Equal(t, []queryTotal{{"east", 2}, {"north", 3}, {"south", 5}}, r.AsSlice())
+}
+
+func TestQuery_GroupBy_UnhashableKeys(t *testing.T) {
+	m := NewMapper(NewConverter(func(v []string) (string, error) {
+		return v[0], nil
+	}))
+	s := Of([]string{"a", "b"}, []string{"c"}, []string{"a", "b"}, []string{"d"}, []string{"c"})
+	r, err := Select[[]string, string](m).From(s).GroupBy(func(v []string) any {
+		return v
+	}).Execute()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "a", "c", "c", "d"}, r.AsSlice())
+}
+
+func TestQuery_GroupBy_UnhashableInComparableKeys(t *testing.T) {
+	type key struct {
+		v any
+	}
+	m := NewMapper(NewConverter(func(v []string) (string, error) {
+		return v[0], nil
+	}))
+	s := Of([]string{"a", "b"}, []string{"c"}, []string{"a", "b"}, []string{"d"}, []string{"c"})
+	r, err := Select[[]string, string](m).From(s).GroupBy(func(v []string) any {
+		return key{v}
+	}).Execute()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "a", "c", "c", "d"}, r.AsSlice())
+}
+
+type queryCountMapper[T any] struct{}
+
+func (m queryCountMapper[T]) Map(in Stream[T]) (Stream[int], error) {
+	return Of(in.Len()), nil
+}
+
+func TestQuery_GroupBy_NaNKeys(t *testing.T) {
+	nan := math.NaN()
+	r, err := Select[float64, int](queryCountMapper[float64]{}).From(Of(nan, nan, 1.0, nan)).GroupBy(func(v float64) any {
+		return v
+	}).Execute()
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 1}, r.AsSlice())
+}
+
+func TestQuery_Execute_Errors(t *testing.T) {
+	m := NewMapper(NewConverter(func(v querySale) (int, error) {
+		return 0, errors.New("whoops")
+	}))
+	_, err := Select(m).From(querySales).Execute()
+	require.Error(t, err)
+	_, err = Select(m).From(querySales).GroupBy(func(v querySale) any {
+		return v.region
+	}).Execute()
+	require.Error(t, err)
+}
+
+func TestQuery_Explain(t *testing.T) {
+	q := Select[querySale, queryTotal](queryTotalMapper{})
+	require.Equal(t, "1. FROM     <none> (no results)\n2. SELECT   Map(streams.queryTotalMapper)", q.Explain())
+
+	q = q.From(querySales).
+		Where(NewPredicate(func(v querySale) bool {
+			return true
+		})).
+		GroupBy(func(v querySale) any {
+			return v.region
+		}).
+		Having(NewPredicate(func(v queryTotal) bool {
+			return true
+		})).
+		OrderBy(NewComparator(func(v1, v2 queryTotal) int {
+			return 0
+		})).
+		Limit(10).Offset(5)
+	const expect = `1. FROM     *streams.stream[streams.querySale]
+2. WHERE    Filter(streams.predicate[streams.querySale])
+3. GROUP BY group by key func(streams.querySale) interface {} (first-encounter order)
+4. SELECT   Map(streams.queryTotalMapper) per group
+5. HAVING   Filter(streams.predicate[streams.queryTotal])
+6. ORDER BY Sorted(*streams.comparator[streams.queryTotal])
+7. OFFSET   Skip(5)
+8. LIMIT    Limit(10)`
+	require.Equal(t, expect, q.Explain())
+}
@@ -0,0 +1,91 @@
+package streams
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Shuffle creates a new stream of the elements of the supplied stream in a random order
+//
+// the randomness is taken from the provided source - so using a seeded source gives reproducible results
+// (if the provided source is nil, a time seeded source is used)
+func Shuffle[T any](s Stream[T], src rand.Source) Stream[T] {
+	es := append(make([]T, 0, s.Len()), s.AsSlice()...)
+	rnd := newRand(src)
+	rnd.Shuffle(len(es), func(i, j int) {
+		es[i], es[j] = es[j], es[i]
+	})
+	return &stream[T]{
+		elements: es,
+	}
+}
+
+// Sample creates a new stream of n randomly chosen elements (without replacement) of the supplied stream
+//
+// if n is greater than the number of elements in the supplied stream, all elements are returned (in a random order)
+//
+// the randomness is taken from the provided source - so using a seeded source gives reproducible results
+// (if the provided source is nil, a time seeded source is used)
+func Sample[T any](s Stream[T], n int, src rand.Source) Stream[T] {
+	es := append(make([]T, 0, s.Len()), s.AsSlice()...)
+	n = absZero(n)
+	if n > len(es) {
+		n = len(es)
+	}
+	rnd := newRand(src)
+	// partial Fisher-Yates shuffle - only the first n positions need to be chosen...
+	for i := 0; i < n; i++ {
+		j := i + rnd.Intn(len(es)-i)
+		es[i], es[j] = es[j], es[i]
+	}
+	return &stream[T]{
+		elements: es[:n],
+	}
+}
+
+// ReservoirSample creates a new stream of n randomly chosen elements (without replacement) of the supplied stream
+//
+// unlike Sample, the supplied stream is only iterated over once (and its length is never needed) - so it is
+// suitable for lazy streams
+//
+// the randomness is taken from the provided source - so using a seeded source gives reproducible results
+// (if the provided source is nil, a time seeded source is used)
+func ReservoirSample[T any](s Stream[T], n int, src rand.Source) Stream[T] {
+	n = absZero(n)
+	r := &stream[T]{
+		elements: make([]T, 0, n),
+	}
+	rnd := newRand(src)
+	next := s.Iterator()
+	seen := 0
+	for v, ok := next(); ok; v, ok = next() {
+		seen++
+		if len(r.elements) < n {
+			r.elements = append(r.elements, v)
+		} else if j := rnd.Intn(seen); j < n {
+			r.elements[j] = v
+		}
+	}
+	return r
+}
+
+// SampleFraction creates a new stream where each element of the supplied stream is included with
+// the probability p (where p is between 0.0 and 1.0)
+//
+// the resulting stream is lazy - the supplied stream is only iterated over as elements are needed
+//
+// the randomness is taken from the provided source - so using a seeded source gives reproducible results
+// (if the provided source is nil, a time seeded source is used)
+func SampleFraction[T any](s Stream[T], p float64, src rand.Source) Stream[T] {
+	rnd := newRand(src)
+	return newLazyStream[T](s.Iterator(NewPredicate(func(v T) bool {
+		return rnd.Float64() < p
+	})))
+}
+
+func newRand(src rand.Source) *rand.Rand {
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+	return rand.New(src)
+}
@@ -0,0 +1,87 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestShuffle(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	r1 := Shuffle(s, rand.NewSource(42))
+	r2 := Shuffle(s, rand.NewSource(42))
+	require.Equal(t, r1.AsSlice(), r2.AsSlice())
+	require.NotEqual(t, s.AsSlice(), r1.AsSlice())
+	require.ElementsMatch(t, s.AsSlice(), r1.AsSlice())
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, s.AsSlice())
+
+	r := Shuffle(s, nil)
+	require.ElementsMatch(t, s.AsSlice(), r.AsSlice())
+	require.Equal(t, 0, Shuffle(Of[int](), nil).Len())
+}
+
+func TestSample(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	r1 := Sample(s, 4, rand.NewSource(42))
+	r2 := Sample(s, 4, rand.NewSource(42))
+	require.Equal(t, 4, r1.Len())
+	require.Equal(t, r1.AsSlice(), r2.AsSlice())
+	require.Equal(t, 4, r1.Distinct().Len())
+	require.Equal(t, 0, r1.Difference(s, IntComparator).Len())
+
+	require.Equal(t, 10, Sample(s, 20, nil).Len())
+	require.Equal(t, 0, Sample(s, -1, nil).Len())
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, s.AsSlice())
+}
+
+func TestReservoirSample(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	r1 := ReservoirSample(s, 4, rand.NewSource(42))
+	r2 := ReservoirSample(s, 4, rand.NewSource(42))
+	require.Equal(t, 4, r1.Len())
+	require.Equal(t, r1.AsSlice(), r2.AsSlice())
+	require.Equal(t, 4, r1.Distinct().Len())
+	require.Equal(t, 0, r1.Difference(s, IntComparator).Len())
+
+	require.Equal(t, 10, ReservoirSample(s, 20, nil).Len())
+	require.Equal(t, 0, ReservoirSample(s, 0, nil).Len())
+
+	// each element should be roughly equally likely to be chosen...
+	counts := map[int]int{}
+	src := rand.NewSource(1)
+	for i := 0; i < 2000; i++ {
+		_ = ReservoirSample(s, 2, src).ForEach(NewConsumer(func(v int) error {
+			counts[v]++
+			return nil
+		}))
+	}
+	for v := 1; v <= 10; v++ {
+		require.InDelta(t, 400, counts[v], 80)
+	}
+}
+
+func TestReservoirSample_Lazy(t *testing.T) {
+	s, pulled := testLazyStream(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	r := ReservoirSample[int](s, 3, rand.NewSource(42))
+	require.Equal(t, 3, r.Len())
+	require.Equal(t, 10, *pulled)
+}
+
+func TestSampleFraction(t *testing.T) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	s := Of(values...)
+	r1 := SampleFraction(s, 0.25, rand.NewSource(42))
+	r2 := SampleFraction(s, 0.25, rand.NewSource(42))
+	require.Equal(t, r1.AsSlice(), r2.AsSlice())
+	require.InDelta(t, 250, r1.Len(), 50)
+	require.Equal(t, 1000, SampleFraction(s, 1, nil).Len())
+	require.Equal(t, 0, SampleFraction(s, 0, nil).Len())
+
+	ls, pulled := testLazyStream(values...)
+	r := SampleFraction[int](ls, 0.5, rand.NewSource(42))
+	require.Equal(t, 2, r.Limit(2).Len())
+	require.Less(t, *pulled, 1000)
+}
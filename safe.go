@@ -0,0 +1,95 @@
+package streams
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error returned when a panic is recovered by one of the safe wrappers (SafePredicate, SafeConsumer,
+// SafeConverter or SafeComparator)
+type PanicError[T any] struct {
+	// Values are the element(s) being processed when the panic occurred (a comparator panic has two elements)
+	Values []T
+	// Recovered is the value recovered from the panic
+	Recovered any
+	// Stack is the stack trace at the point of the panic
+	Stack []byte
+}
+
+func (e *PanicError[T]) Error() string {
+	return fmt.Sprintf("recovered panic processing %v: %v", e.Values, e.Recovered)
+}
+
+// Unwrap returns the recovered value if it is an error (otherwise nil)
+func (e *PanicError[T]) Unwrap() error {
+	if err, ok := e.Recovered.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// SafePredicate creates a TryPredicate from the supplied Predicate - where a panic in the predicate is recovered
+// and returned as a *PanicError
+func SafePredicate[T any](p Predicate[T]) TryPredicate[T] {
+	return safeTryPredicate(AsTryPredicate(p))
+}
+
+// SafeConsumer creates a new Consumer from the supplied Consumer - where a panic in the consumer is recovered
+// and returned as a *PanicError
+func SafeConsumer[T any](c Consumer[T]) Consumer[T] {
+	if c == nil {
+		return nil
+	}
+	return NewConsumer(func(v T) (err error) {
+		defer recoverPanic(&err, v)
+		return c.Accept(v)
+	})
+}
+
+// SafeConverter creates a new Converter from the supplied Converter - where a panic in the converter is recovered
+// and returned as a *PanicError
+func SafeConverter[T any, R any](c Converter[T, R]) Converter[T, R] {
+	if c == nil {
+		return nil
+	}
+	return NewConverter(func(v T) (result R, err error) {
+		defer recoverPanic(&err, v)
+		return c.Convert(v)
+	})
+}
+
+// SafeComparator creates a TryComparator from the supplied Comparator - where a panic in the comparator is recovered
+// and returned as a *PanicError
+func SafeComparator[T any](c Comparator[T]) TryComparator[T] {
+	return safeTryComparator(AsTryComparator(c))
+}
+
+func safeTryPredicate[T any](p TryPredicate[T]) TryPredicate[T] {
+	if p == nil {
+		return nil
+	}
+	return NewTryPredicate(func(v T) (result bool, err error) {
+		defer recoverPanic(&err, v)
+		return p.Test(v)
+	})
+}
+
+func safeTryComparator[T any](c TryComparator[T]) TryComparator[T] {
+	if c == nil {
+		return nil
+	}
+	return NewTryComparator(func(v1, v2 T) (result int, err error) {
+		defer recoverPanic(&err, v1, v2)
+		return c.Compare(v1, v2)
+	})
+}
+
+func recoverPanic[T any](err *error, values ...T) {
+	if r := recover(); r != nil {
+		*err = &PanicError[T]{
+			Values:    values,
+			Recovered: r,
+			Stack:     debug.Stack(),
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPanicError(t *testing.T) {
+	err := &PanicError[string]{Values: []string{"a"}, Recovered: "fooey"}
+	require.Equal(t, "recovered panic processing [a]: fooey", err.Error())
+	require.Nil(t, err.Unwrap())
+
+	cause := errors.New("whoops")
+	err = &PanicError[string]{Values: []string{"a"}, Recovered: cause}
+	require.True(t, errors.Is(err, cause))
+}
+
+func TestSafePredicate(t *testing.T) {
+	p := SafePredicate(NewPredicate(func(v string) bool {
+		if v == "" {
+			panic("empty")
+		}
+		return v == "a"
+	}))
+	ok, err := p.Test("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = p.Test("")
+	require.Error(t, err)
+	require.False(t, ok)
+	var perr *PanicError[string]
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, []string{""}, perr.Values)
+	require.Equal(t, "empty", perr.Recovered)
+	require.NotEmpty(t, perr.Stack)
+
+	require.Nil(t, SafePredicate[string](nil))
+}
+
+func TestSafeConsumer(t *testing.T) {
+	collected := make([]string, 0)
+	c := SafeConsumer(NewConsumer(func(v string) error {
+		if v == "" {
+			panic("empty")
+		}
+		collected = append(collected, v)
+		return nil
+	}))
+	err := Of("a", "b", "", "c").ForEach(c)
+	require.Error(t, err)
+	require.Equal(t, []string{"a", "b"}, collected)
+	var perr *PanicError[string]
+	require.True(t, errors.As(err, &perr))
+
+	c = SafeConsumer(NewConsumer(func(v string) error {
+		return errors.New("fooey")
+	}))
+	err = Of("a").ForEach(c)
+	require.Error(t, err)
+	require.Equal(t, "fooey", err.Error())
+
+	require.Nil(t, SafeConsumer[string](nil))
+}
+
+func TestSafeConverter(t *testing.T) {
+	c := SafeConverter(NewConverter(func(v string) (int, error) {
+		return len(v) / (len(v) - 1), nil
+	}))
+	m := NewMapper(c)
+	out, err := m.Map(Of("aa", "bbb"))
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 1}, out.AsSlice())
+	_, err = m.Map(Of("aa", "b"))
+	require.Error(t, err)
+	var perr *PanicError[string]
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, []string{"b"}, perr.Values)
+	// runtime errors are unwrappable...
+	var rerr interface{ RuntimeError() }
+	require.True(t, errors.As(err, &rerr))
+
+	require.Nil(t, SafeConverter[string, int](nil))
+}
+
+func TestSafeComparator(t *testing.T) {
+	c := SafeComparator(NewComparator(func(v1, v2 *string) int {
+		return StringComparator.Compare(*v1, *v2)
+	}))
+	a, b := "a", "b"
+	r, err := c.Compare(&a, &b)
+	require.NoError(t, err)
+	require.Equal(t, -1, r)
+	_, err = c.Compare(&a, nil)
+	require.Error(t, err)
+	var perr *PanicError[*string]
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, 2, len(perr.Values))
+
+	require.Nil(t, SafeComparator[string](nil))
+}
@@ -0,0 +1,290 @@
+package streams
+
+import (
+	"sort"
+)
+
+// SortedStream is a Stream whose elements are known to be sorted according to a Comparator
+//
+// A SortedStream is returned by Stream.Sorted - and remembers the comparator used to sort, so that lookups can use
+// binary search and set operations (SortedStream.Union, SortedStream.Intersection and SortedStream.Difference) can
+// use linear merge algorithms when both operands are sorted by the same comparator
+type SortedStream[T any] interface {
+	Stream[T]
+	// Comparator returns the comparator by which this stream is sorted
+	//
+	// Note: the comparator is nil if the stream was sorted with a nil comparator (i.e. was not sorted)
+	Comparator() Comparator[T]
+	// BinarySearch searches for the specified value and returns the index of the first element that is equal to it and true,
+	// or, if there is no equal element, the index at which the value would be inserted and false
+	//
+	// if this stream has no comparator, always returns -1 and false
+	BinarySearch(v T) (int, bool)
+	// LowerBound returns the index of the first element that is not less than the specified value
+	//
+	// if this stream has no comparator, always returns -1
+	LowerBound(v T) int
+	// UpperBound returns the index of the first element that is greater than the specified value
+	//
+	// if this stream has no comparator, always returns -1
+	UpperBound(v T) int
+	// Range creates a new sorted stream of the elements that are greater than or equal to from and less than to
+	//
+	// if this stream has no comparator, the result is always empty
+	Range(from, to T) SortedStream[T]
+}
+
+// newSortedStream creates a new sorted stream from a copy of the supplied elements
+func newSortedStream[T any](elements []T, c Comparator[T]) *sortedStream[T] {
+	es := make([]T, 0, len(elements))
+	es = append(es, elements...)
+	if c != nil {
+		sort.Slice(es, func(i, j int) bool {
+			return c.Less(es[i], es[j])
+		})
+	}
+	return &sortedStream[T]{
+		stream: stream[T]{
+			elements: es,
+		},
+		c: c,
+	}
+}
+
+type sortedStream[T any] struct {
+	stream[T]
+	c Comparator[T]
+}
+
+// Comparator returns the comparator by which this stream is sorted
+//
+// Note: the comparator is nil if the stream was sorted with a nil comparator (i.e. was not sorted)
+func (s *sortedStream[T]) Comparator() Comparator[T] {
+	return s.c
+}
+
+// BinarySearch searches for the specified value and returns the index of the first element that is equal to it and true,
+// or, if there is no equal element, the index at which the value would be inserted and false
+//
+// if this stream has no comparator, always returns -1 and false
+func (s *sortedStream[T]) BinarySearch(v T) (int, bool) {
+	if s.c == nil {
+		return -1, false
+	}
+	i := s.LowerBound(v)
+	return i, i < len(s.elements) && s.c.Compare(s.elements[i], v) == 0
+}
+
+// LowerBound returns the index of the first element that is not less than the specified value
+//
+// if this stream has no comparator, always returns -1
+func (s *sortedStream[T]) LowerBound(v T) int {
+	if s.c == nil {
+		return -1
+	}
+	return sort.Search(len(s.elements), func(i int) bool {
+		return s.c.Compare(s.elements[i], v) >= 0
+	})
+}
+
+// UpperBound returns the index of the first element that is greater than the specified value
+//
+// if this stream has no comparator, always returns -1
+func (s *sortedStream[T]) UpperBound(v T) int {
+	if s.c == nil {
+		return -1
+	}
+	return sort.Search(len(s.elements), func(i int) bool {
+		return s.c.Compare(s.elements[i], v) > 0
+	})
+}
+
+// Range creates a new sorted stream of the elements that are greater than or equal to from and less than to
+//
+// if this stream has no comparator, the result is always empty
+func (s *sortedStream[T]) Range(from, to T) SortedStream[T] {
+	r := &sortedStream[T]{
+		c: s.c,
+	}
+	if s.c != nil {
+		if start, end := s.LowerBound(from), s.LowerBound(to); start < end {
+			r.elements = append(make([]T, 0, end-start), s.elements[start:end]...)
+		}
+	}
+	return r
+}
+
+// Difference creates a new stream that is the set difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+//
+// if the other stream is also a SortedStream and both are sorted by the provided comparator, a linear merge is used
+func (s *sortedStream[T]) Difference(other Stream[T], c Comparator[T]) Stream[T] {
+	if os, ok := s.mergeable(other, c); ok {
+		return s.merge(os, true, false, false)
+	}
+	return s.stream.Difference(other, c)
+}
+
+// Filter creates a new stream of elements in this stream that match the provided predicate
+//
+// if the provided predicate is nil, all elements in this stream are returned
+func (s *sortedStream[T]) Filter(p Predicate[T]) Stream[T] {
+	return &sortedStream[T]{
+		stream: *(s.stream.Filter(p).(*stream[T])),
+		c:      s.c,
+	}
+}
+
+// Has returns whether this stream contains an element that is equal to the element value provided
+//
+// equality is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false
+//
+// if the provided comparator is the comparator by which this stream is sorted, a binary search is used
+func (s *sortedStream[T]) Has(v T, c Comparator[T]) bool {
+	if sameComparator(c, s.c) {
+		_, found := s.BinarySearch(v)
+		return found
+	}
+	return s.stream.Has(v, c)
+}
+
+// Intersection creates a new stream that is the set intersection of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+//
+// if the other stream is also a SortedStream and both are sorted by the provided comparator, a linear merge is used
+func (s *sortedStream[T]) Intersection(other Stream[T], c Comparator[T]) Stream[T] {
+	if os, ok := s.mergeable(other, c); ok {
+		return s.merge(os, false, true, false)
+	}
+	return s.stream.Intersection(other, c)
+}
+
+// Limit creates a new stream whose number of elements is limited to the value provided
+//
+// if the maximum size is greater than the length of this stream, all elements are returned
+func (s *sortedStream[T]) Limit(maxSize int) Stream[T] {
+	return &sortedStream[T]{
+		stream: *(s.stream.Limit(maxSize).(*stream[T])),
+		c:      s.c,
+	}
+}
+
+// Peek creates a new stream with the same elements as this stream, additionally performing the provided action on each element
+//
+// Peek is intended for debugging - for example, to see elements as they flow through a chain of operations
+//
+// any error returned by the consumer is ignored, and if the provided consumer is nil, nothing is performed
+func (s *sortedStream[T]) Peek(c Consumer[T]) Stream[T] {
+	return &sortedStream[T]{
+		stream: *(s.stream.Peek(c).(*stream[T])),
+		c:      s.c,
+	}
+}
+
+// Skip creates a new stream consisting of this stream after discarding the first n elements
+//
+// if the specified n to skip is equal to or greater than the number of elements in this stream,
+// an empty stream is returned
+func (s *sortedStream[T]) Skip(n int) Stream[T] {
+	return &sortedStream[T]{
+		stream: *(s.stream.Skip(n).(*stream[T])),
+		c:      s.c,
+	}
+}
+
+// Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
+//
+// if the provided comparator is nil, the elements are not sorted
+func (s *sortedStream[T]) Sorted(c Comparator[T]) SortedStream[T] {
+	if sameComparator(c, s.c) {
+		return &sortedStream[T]{
+			stream: stream[T]{
+				elements: append(make([]T, 0, len(s.elements)), s.elements...),
+			},
+			c: s.c,
+		}
+	}
+	return newSortedStream[T](s.elements, c)
+}
+
+// SymmetricDifference creates a new stream that is the set symmetric difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+//
+// if the other stream is also a SortedStream and both are sorted by the provided comparator, a linear merge is used
+func (s *sortedStream[T]) SymmetricDifference(other Stream[T], c Comparator[T]) Stream[T] {
+	if os, ok := s.mergeable(other, c); ok {
+		return s.merge(os, true, false, true)
+	}
+	return s.stream.SymmetricDifference(other, c)
+}
+
+// Union creates a new stream that is the set union of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+//
+// if the other stream is also a SortedStream and both are sorted by the provided comparator, a linear merge is used
+func (s *sortedStream[T]) Union(other Stream[T], c Comparator[T]) Stream[T] {
+	if os, ok := s.mergeable(other, c); ok {
+		return s.merge(os, true, true, true)
+	}
+	return s.stream.Union(other, c)
+}
+
+// mergeable determines whether a set operation with the other stream can use a linear merge - i.e. both streams
+// are sorted by the provided comparator
+func (s *sortedStream[T]) mergeable(other Stream[T], c Comparator[T]) (SortedStream[T], bool) {
+	if os, ok := other.(SortedStream[T]); ok && sameComparator(c, s.c) && sameComparator(c, os.Comparator()) {
+		return os, true
+	}
+	return nil, false
+}
+
+// merge performs a linear merge of this and the other sorted stream
+//
+// for each distinct value (run of equal elements) the elements of this stream are kept if the value is only in this stream
+// (onlyThis) or is in both streams (both) - and the elements of the other stream are kept if the value is only in the other
+// stream (onlyOther)
+func (s *sortedStream[T]) merge(other SortedStream[T], onlyThis bool, both bool, onlyOther bool) Stream[T] {
+	r := &sortedStream[T]{
+		c: s.c,
+	}
+	as, bs := s.elements, other.AsSlice()
+	i, j := 0, 0
+	for i < len(as) || j < len(bs) {
+		cmp := 0
+		if i >= len(as) {
+			cmp = 1
+		} else if j >= len(bs) {
+			cmp = -1
+		} else {
+			cmp = s.c.Compare(as[i], bs[j])
+		}
+		switch {
+		case cmp < 0:
+			if onlyThis {
+				r.elements = append(r.elements, as[i])
+			}
+			i++
+		case cmp > 0:
+			if onlyOther {
+				r.elements = append(r.elements, bs[j])
+			}
+			j++
+		default:
+			v := as[i]
+			for ; i < len(as) && s.c.Compare(as[i], v) == 0; i++ {
+				if both {
+					r.elements = append(r.elements, as[i])
+				}
+			}
+			for ; j < len(bs) && s.c.Compare(bs[j], v) == 0; j++ {
+			}
+		}
+	}
+	return r
+}
@@ -0,0 +1,173 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSortedStream_Comparator(t *testing.T) {
+	s := Of(3, 1, 2).Sorted(IntComparator)
+	require.Equal(t, []int{1, 2, 3}, s.AsSlice())
+	require.True(t, sameComparator(IntComparator, s.Comparator()))
+
+	s = Of(3, 1, 2).Sorted(nil)
+	require.Equal(t, []int{3, 1, 2}, s.AsSlice())
+	require.Nil(t, s.Comparator())
+}
+
+func TestSortedStream_BinarySearch(t *testing.T) {
+	s := Of(5, 1, 3, 3, 7).Sorted(IntComparator)
+	i, found := s.BinarySearch(3)
+	require.True(t, found)
+	require.Equal(t, 1, i)
+	i, found = s.BinarySearch(4)
+	require.False(t, found)
+	require.Equal(t, 3, i)
+	i, found = s.BinarySearch(0)
+	require.False(t, found)
+	require.Equal(t, 0, i)
+	i, found = s.BinarySearch(8)
+	require.False(t, found)
+	require.Equal(t, 5, i)
+
+	s = Of(5, 1, 3).Sorted(nil)
+	i, found = s.BinarySearch(3)
+	require.False(t, found)
+	require.Equal(t, -1, i)
+}
+
+func TestSortedStream_Bounds(t *testing.T) {
+	s := Of(5, 1, 3, 3, 7).Sorted(IntComparator)
+	require.Equal(t, 1, s.LowerBound(3))
+	require.Equal(t, 3, s.UpperBound(3))
+	require.Equal(t, 3, s.LowerBound(4))
+	require.Equal(t, 3, s.UpperBound(4))
+	require.Equal(t, 0, s.LowerBound(0))
+	require.Equal(t, 5, s.UpperBound(7))
+
+	s = Of(5, 1, 3).Sorted(nil)
+	require.Equal(t, -1, s.LowerBound(3))
+	require.Equal(t, -1, s.UpperBound(3))
+}
+
+func TestSortedStream_Range(t *testing.T) {
+	s := Of(5, 1, 3, 3, 7, 9).Sorted(IntComparator)
+	r := s.Range(3, 7)
+	require.Equal(t, []int{3, 3, 5}, r.AsSlice())
+	require.True(t, r.Has(5, IntComparator))
+	r = s.Range(4, 100)
+	require.Equal(t, []int{5, 7, 9}, r.AsSlice())
+	r = s.Range(7, 3)
+	require.Equal(t, 0, r.Len())
+
+	s = Of(5, 1, 3).Sorted(nil)
+	require.Equal(t, 0, s.Range(1, 5).Len())
+
+	// range does not share storage with the parent stream...
+	s = Of(5, 1, 3).Sorted(IntComparator)
+	r = s.Range(1, 4)
+	r.AsSlice()[0] = 99
+	require.Equal(t, []int{1, 3, 5}, s.AsSlice())
+}
+
+func TestSortedStream_Has(t *testing.T) {
+	s := Of("b", "a", "c").Sorted(StringComparator)
+	require.True(t, s.Has("a", StringComparator))
+	require.False(t, s.Has("A", StringComparator))
+	require.True(t, s.Has("A", StringInsensitiveComparator))
+	require.False(t, s.Has("a", nil))
+}
+
+func TestSortedStream_KeepsSorted(t *testing.T) {
+	s := Of(5, 1, 3, 3, 7, 9).Sorted(IntComparator)
+	r := s.Filter(NewPredicate(func(v int) bool {
+		return v > 1
+	}))
+	rs, ok := r.(SortedStream[int])
+	require.True(t, ok)
+	require.Equal(t, []int{3, 3, 5, 7, 9}, rs.AsSlice())
+	rs, ok = s.Limit(2).(SortedStream[int])
+	require.True(t, ok)
+	require.Equal(t, []int{1, 3}, rs.AsSlice())
+	rs, ok = s.Skip(4).(SortedStream[int])
+	require.True(t, ok)
+	require.Equal(t, []int{7, 9}, rs.AsSlice())
+
+	rs = s.Sorted(IntComparator)
+	require.Equal(t, s.AsSlice(), rs.AsSlice())
+	rs = s.Sorted(IntComparator.Reversed())
+	require.Equal(t, []int{9, 7, 5, 3, 3, 1}, rs.AsSlice())
+}
+
+func TestSortedStream_SetOperations(t *testing.T) {
+	s1 := Of(5, 1, 3, 3, 7).Sorted(IntComparator)
+	s2 := Of(3, 4, 7, 7, 8).Sorted(IntComparator)
+	unsorted := Of(3, 4, 7, 7, 8)
+
+	testCases := []struct {
+		name   string
+		op     func(other Stream[int]) Stream[int]
+		expect []int
+	}{
+		{
+			name: "Union",
+			op: func(other Stream[int]) Stream[int] {
+				return s1.Union(other, IntComparator)
+			},
+			expect: []int{1, 3, 3, 4, 5, 7, 8},
+		},
+		{
+			name: "Intersection",
+			op: func(other Stream[int]) Stream[int] {
+				return s1.Intersection(other, IntComparator)
+			},
+			expect: []int{3, 3, 7},
+		},
+		{
+			name: "Difference",
+			op: func(other Stream[int]) Stream[int] {
+				return s1.Difference(other, IntComparator)
+			},
+			expect: []int{1, 5},
+		},
+		{
+			name: "SymmetricDifference",
+			op: func(other Stream[int]) Stream[int] {
+				return s1.SymmetricDifference(other, IntComparator)
+			},
+			expect: []int{1, 4, 5, 8},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged := tc.op(s2)
+			_, ok := merged.(SortedStream[int])
+			require.True(t, ok)
+			require.Equal(t, tc.expect, merged.AsSlice())
+			// same elements (though not necessarily same order) when not merged...
+			unmerged := tc.op(unsorted)
+			_, ok = unmerged.(SortedStream[int])
+			require.False(t, ok)
+			require.ElementsMatch(t, tc.expect, unmerged.AsSlice())
+		})
+	}
+
+	// different comparators - not merged...
+	r := s1.Intersection(s2, NewComparator(IntComparator.Compare))
+	_, ok := r.(SortedStream[int])
+	require.False(t, ok)
+	require.Equal(t, []int{3, 3, 7}, r.AsSlice())
+	require.Equal(t, 0, s1.Union(s2, nil).Len())
+}
+
+func TestSortedStream_Peek(t *testing.T) {
+	s := Of(3, 1, 2).Sorted(IntComparator)
+	peeked := make([]int, 0)
+	r := s.Peek(NewConsumer(func(v int) error {
+		peeked = append(peeked, v)
+		return nil
+	}))
+	require.Equal(t, []int{1, 2, 3}, peeked)
+	_, ok := r.(SortedStream[int])
+	require.True(t, ok)
+}
@@ -0,0 +1,169 @@
+package streams
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// PredicateSpec is a serialisable (e.g. JSON) specification of a predicate - which can be compiled into a Predicate
+// using CompilePredicate
+//
+// a spec is either a condition (Field, Op and Value) or a combination of other specs (And, Or or Not) - for example
+//  {"and": [{"field": "status", "op": "eq", "value": "open"}, {"not": {"field": "priority", "op": "lt", "value": 3}}]}
+//
+// ops are either symbolic (==, !=, <, <=, >, >=) or named (eq, ne, lt, lte, gt, gte) - plus in, nin (or "not in"),
+// like and "not like" (see ParsePredicate for the meaning of ops and fields)
+type PredicateSpec struct {
+	Field      string           `json:"field,omitempty"`
+	Op         string           `json:"op,omitempty"`
+	Value      any              `json:"value,omitempty"`
+	IgnoreCase bool             `json:"ignoreCase,omitempty"` // IgnoreCase indicates that string comparisons are case-insensitive
+	And        []*PredicateSpec `json:"and,omitempty"`
+	Or         []*PredicateSpec `json:"or,omitempty"`
+	Not        *PredicateSpec   `json:"not,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler - so that empty (but non-nil) And or Or specs are not omitted
+func (s PredicateSpec) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Field      string            `json:"field,omitempty"`
+		Op         string            `json:"op,omitempty"`
+		Value      any               `json:"value,omitempty"`
+		IgnoreCase bool              `json:"ignoreCase,omitempty"`
+		And        *[]*PredicateSpec `json:"and,omitempty"`
+		Or         *[]*PredicateSpec `json:"or,omitempty"`
+		Not        *PredicateSpec    `json:"not,omitempty"`
+	}{
+		Field:      s.Field,
+		Op:         s.Op,
+		Value:      s.Value,
+		IgnoreCase: s.IgnoreCase,
+		Not:        s.Not,
+	}
+	if s.And != nil {
+		out.And = &s.And
+	}
+	if s.Or != nil {
+		out.Or = &s.Or
+	}
+	return json.Marshal(out)
+}
+
+// CompilePredicate compiles a PredicateSpec into a Predicate for values of type T (which should be a struct type, or pointer
+// to struct type)
+//
+// an error is returned if the spec is invalid (e.g. has more than one of a condition, And, Or or Not) or refers to
+// unknown fields, or compares fields with incompatible values
+func CompilePredicate[T any](spec *PredicateSpec) (Predicate[T], error) {
+	return compilePredicateSpec[T](reflect.TypeOf((*T)(nil)).Elem(), spec, "spec")
+}
+
+func compilePredicateSpec[T any](t reflect.Type, spec *PredicateSpec, path string) (Predicate[T], error) {
+	if spec == nil {
+		return nil, fmt.Errorf("%s: nil spec", path)
+	}
+	kinds := 0
+	for _, set := range []bool{spec.Field != "" || spec.Op != "", spec.And != nil, spec.Or != nil, spec.Not != nil} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return nil, fmt.Errorf("%s: spec must have exactly one of field/op, and, or, not", path)
+	}
+	switch {
+	case spec.And != nil, spec.Or != nil:
+		name, specs, combine := "and", spec.And, AllOf[T]
+		if spec.Or != nil {
+			name, specs, combine = "or", spec.Or, AnyOf[T]
+		}
+		ps := make([]Predicate[T], len(specs))
+		for i, s := range specs {
+			p, err := compilePredicateSpec[T](t, s, fmt.Sprintf("%s.%s[%d]", path, name, i))
+			if err != nil {
+				return nil, err
+			}
+			ps[i] = p
+		}
+		return combine(ps...), nil
+	case spec.Not != nil:
+		p, err := compilePredicateSpec[T](t, spec.Not, path+".not")
+		if err != nil {
+			return nil, err
+		}
+		return p.Negate(), nil
+	}
+	cond, err := newCondition(t, spec.Field, spec.Op, spec.Value, spec.IgnoreCase)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return conditionPredicate[T](cond), nil
+}
+
+// SortDirection is the direction of a SortFieldSpec
+type SortDirection string
+
+const (
+	Ascending  SortDirection = "asc"  // Ascending sort direction (the default)
+	Descending SortDirection = "desc" // Descending sort direction
+)
+
+// NullsOrder is the placement of null values for a SortFieldSpec
+type NullsOrder string
+
+const (
+	NullsLast  NullsOrder = "last"  // NullsLast null values are placed after all non-null values (the default)
+	NullsFirst NullsOrder = "first" // NullsFirst null values are placed before all non-null values
+)
+
+// ComparatorSpec is a serialisable (e.g. JSON) specification of a comparator - which can be compiled into a Comparator
+// using CompileComparator
+//
+// the comparator compares by each of the fields in turn - for example
+//  {"fields": [{"field": "priority", "direction": "desc"}, {"field": "assignee.name", "nulls": "first", "ignoreCase": true}]}
+type ComparatorSpec struct {
+	Fields []SortFieldSpec `json:"fields"`
+}
+
+// SortFieldSpec is a field of a ComparatorSpec
+type SortFieldSpec struct {
+	Field      string        `json:"field"`
+	Direction  SortDirection `json:"direction,omitempty"`
+	Nulls      NullsOrder    `json:"nulls,omitempty"`      // Nulls is the placement of nulls - which is not affected by the direction
+	IgnoreCase bool          `json:"ignoreCase,omitempty"` // IgnoreCase indicates that strings are compared case-insensitively
+}
+
+// CompileComparator compiles a ComparatorSpec into a Comparator for values of type T (which should be a struct type, or
+// pointer to struct type)
+//
+// an error is returned if the spec is invalid (e.g. has an unknown direction) or refers to unknown or non-sortable fields
+func CompileComparator[T any](spec *ComparatorSpec) (Comparator[T], error) {
+	if spec == nil || len(spec.Fields) == 0 {
+		return nil, errors.New("spec: no fields")
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	cmps := make([]func(a, b reflect.Value) int, len(spec.Fields))
+	for i, f := range spec.Fields {
+		if f.Direction != "" && f.Direction != Ascending && f.Direction != Descending {
+			return nil, fmt.Errorf("spec.fields[%d]: unknown direction %q", i, f.Direction)
+		} else if f.Nulls != "" && f.Nulls != NullsFirst && f.Nulls != NullsLast {
+			return nil, fmt.Errorf("spec.fields[%d]: unknown nulls order %q", i, f.Nulls)
+		}
+		cmp, err := newFieldComparison(t, f.Field, f.Direction == Descending, f.Nulls == NullsFirst, f.IgnoreCase)
+		if err != nil {
+			return nil, fmt.Errorf("spec.fields[%d]: %w", i, err)
+		}
+		cmps[i] = cmp
+	}
+	return NewComparator(func(v1, v2 T) int {
+		a, b := reflect.ValueOf(&v1).Elem(), reflect.ValueOf(&v2).Elem()
+		for _, cmp := range cmps {
+			if c := cmp(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}), nil
+}
@@ -0,0 +1,134 @@
+package streams
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompilePredicate(t *testing.T) {
+	issues := []exprIssue{
+		{Status: "open", Priority: 3, Assignee: &exprUser{Name: "Bilbo"}},
+		{Status: "open", Priority: 1},
+		{Status: "closed", Priority: 5, Assignee: &exprUser{Name: "Sam"}},
+		{Status: "Open", Priority: 4},
+	}
+	testCases := []struct {
+		json   string
+		expect []int
+	}{
+		{`{"field": "status", "op": "eq", "value": "open"}`, []int{0, 1}},
+		{`{"field": "status", "op": "==", "value": "open", "ignoreCase": true}`, []int{0, 1, 3}},
+		{`{"and": [{"field": "status", "op": "eq", "value": "open"}, {"not": {"field": "priority", "op": "lt", "value": 3}}]}`, []int{0}},
+		{`{"or": [{"field": "priority", "op": "gte", "value": 5}, {"field": "assignee.name", "op": "like", "value": "B%"}]}`, []int{0, 2}},
+		{`{"field": "priority", "op": "in", "value": [1, 4]}`, []int{1, 3}},
+		{`{"field": "priority", "op": "nin", "value": [1, 4]}`, []int{0, 2}},
+		{`{"field": "assignee", "op": "eq"}`, []int{1, 3}},
+		{`{"field": "assignee", "op": "ne"}`, []int{0, 2}},
+		{`{"and": []}`, []int{0, 1, 2, 3}},
+		{`{"or": []}`, []int{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.json, func(t *testing.T) {
+			spec := &PredicateSpec{}
+			require.NoError(t, json.Unmarshal([]byte(tc.json), spec))
+			p, err := CompilePredicate[exprIssue](spec)
+			require.NoError(t, err)
+			matched := make([]int, 0)
+			for i, v := range issues {
+				if p.Test(v) {
+					matched = append(matched, i)
+				}
+			}
+			require.Equal(t, tc.expect, matched)
+
+			// round trip...
+			data, err := json.Marshal(spec)
+			require.NoError(t, err)
+			spec2 := &PredicateSpec{}
+			require.NoError(t, json.Unmarshal(data, spec2))
+			require.Equal(t, spec, spec2)
+		})
+	}
+}
+
+func TestCompilePredicate_Errors(t *testing.T) {
+	testCases := []struct {
+		spec   *PredicateSpec
+		expect string
+	}{
+		{nil, "spec: nil spec"},
+		{&PredicateSpec{}, "spec: spec must have exactly one of field/op, and, or, not"},
+		{&PredicateSpec{Field: "status", Op: "eq", Not: &PredicateSpec{}}, "spec: spec must have exactly one of field/op, and, or, not"},
+		{&PredicateSpec{Field: "foo", Op: "eq", Value: 1}, `spec: unknown field "foo"`},
+		{&PredicateSpec{Field: "status", Op: "foo"}, `spec: unknown operator "foo"`},
+		{&PredicateSpec{And: []*PredicateSpec{{Field: "status", Op: "eq"}, {Or: []*PredicateSpec{nil}}}}, `spec.and[1].or[0]: nil spec`},
+		{&PredicateSpec{Not: &PredicateSpec{Field: "status", Op: "eq", Value: 1}}, `spec.not: cannot compare field "status" (string) with 1`},
+	}
+	for _, tc := range testCases {
+		_, err := CompilePredicate[exprIssue](tc.spec)
+		require.EqualError(t, err, tc.expect)
+	}
+}
+
+func TestCompileComparator(t *testing.T) {
+	issues := []exprIssue{
+		{Status: "b", Priority: 1, Assignee: &exprUser{Name: "Sam"}},
+		{Status: "A", Priority: 3},
+		{Status: "c", Priority: 1, Assignee: &exprUser{Name: "bilbo"}},
+		{Status: "a", Priority: 3, Assignee: &exprUser{Name: "Frodo"}},
+	}
+	statuses := func(s Stream[exprIssue]) []string {
+		r := make([]string, 0)
+		_ = s.ForEach(NewConsumer(func(v exprIssue) error {
+			r = append(r, v.Status)
+			return nil
+		}))
+		return r
+	}
+	testCases := []struct {
+		json   string
+		expect []string
+	}{
+		{`{"fields": [{"field": "status"}]}`, []string{"A", "a", "b", "c"}},
+		{`{"fields": [{"field": "status", "direction": "desc"}]}`, []string{"c", "b", "a", "A"}},
+		{`{"fields": [{"field": "priority", "direction": "desc"}, {"field": "status"}]}`, []string{"A", "a", "b", "c"}},
+		{`{"fields": [{"field": "assignee.name"}]}`, []string{"a", "b", "c", "A"}},
+		{`{"fields": [{"field": "assignee.name", "ignoreCase": true}]}`, []string{"c", "a", "b", "A"}},
+		{`{"fields": [{"field": "assignee.name", "nulls": "first", "direction": "desc"}]}`, []string{"A", "c", "b", "a"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.json, func(t *testing.T) {
+			spec := &ComparatorSpec{}
+			require.NoError(t, json.Unmarshal([]byte(tc.json), spec))
+			c, err := CompileComparator[exprIssue](spec)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, statuses(Of(issues...).Sorted(c)))
+
+			// round trip...
+			data, err := json.Marshal(spec)
+			require.NoError(t, err)
+			spec2 := &ComparatorSpec{}
+			require.NoError(t, json.Unmarshal(data, spec2))
+			require.Equal(t, spec, spec2)
+		})
+	}
+}
+
+func TestCompileComparator_Errors(t *testing.T) {
+	testCases := []struct {
+		spec   *ComparatorSpec
+		expect string
+	}{
+		{nil, "spec: no fields"},
+		{&ComparatorSpec{}, "spec: no fields"},
+		{&ComparatorSpec{Fields: []SortFieldSpec{{Field: "foo"}}}, `spec.fields[0]: unknown field "foo"`},
+		{&ComparatorSpec{Fields: []SortFieldSpec{{Field: "status"}, {Field: "status", Direction: "up"}}}, `spec.fields[1]: unknown direction "up"`},
+		{&ComparatorSpec{Fields: []SortFieldSpec{{Field: "status", Nulls: "middle"}}}, `spec.fields[0]: unknown nulls order "middle"`},
+		{&ComparatorSpec{Fields: []SortFieldSpec{{Field: "labels"}}}, `spec.fields[0]: field "labels" ([]string) is not sortable`},
+	}
+	for _, tc := range testCases {
+		_, err := CompileComparator[exprIssue](tc.spec)
+		require.EqualError(t, err, tc.expect)
+	}
+}
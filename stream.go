@@ -2,7 +2,6 @@ package streams
 
 import (
 	"github.com/go-andiamo/gopt"
-	"sort"
 )
 
 // Stream is the main interface for all streams
@@ -28,6 +27,8 @@ type Stream[T any] interface {
 	// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
 	Difference(other Stream[T], c Comparator[T]) Stream[T]
 	// Distinct creates a new stream of distinct elements in this stream
+	//
+	// elements whose values are not hashable (e.g. slices, maps or funcs) are compared using reflect.DeepEqual
 	Distinct() Stream[T]
 	// Filter creates a new stream of elements in this stream that match the provided predicate
 	//
@@ -102,6 +103,21 @@ type Stream[T any] interface {
 	//
 	// if no elements match in the specified position, an empty (not present) optional is returned
 	NthMatch(p Predicate[T], nth int) *gopt.Optional[T]
+	// Page returns the nth page (1 based) of elements of this stream, where each page has the specified size - along with
+	// page metadata (total elements, total pages and whether there are next/previous pages)
+	//
+	// if the page number is out of range or the size is less than 1, the page elements are empty
+	Page(n int, size int) Page[T]
+	// Paginate returns an iterator over the pages of this stream, where each page has the specified page size
+	//
+	// if the page size is less than 1, there are no pages
+	Paginate(pageSize int) Pages[T]
+	// Peek creates a new stream with the same elements as this stream, additionally performing the provided action on each element
+	//
+	// Peek is intended for debugging - for example, to see elements as they flow through a chain of operations
+	//
+	// any error returned by the consumer is ignored, and if the provided consumer is nil, nothing is performed
+	Peek(c Consumer[T]) Stream[T]
 	// Reverse creates a new stream composed of elements from this stream but in reverse order
 	Reverse() Stream[T]
 	// Skip creates a new stream consisting of this stream after discarding the first n elements
@@ -118,8 +134,10 @@ type Stream[T any] interface {
 	Slice(start int, count int) Stream[T]
 	// Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
 	//
+	// the returned SortedStream remembers the comparator - so that lookups and set operations on it can take advantage of the sort order
+	//
 	// if the provided comparator is nil, the elements are not sorted
-	Sorted(c Comparator[T]) Stream[T]
+	Sorted(c Comparator[T]) SortedStream[T]
 	// SymmetricDifference creates a new stream that is the set symmetric difference between this and the supplied other stream
 	//
 	// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
@@ -132,8 +150,7 @@ type Stream[T any] interface {
 	//
 	// uniqueness is determined using the provided comparator
 	//
-	// if provided comparator is nil but the value type of elements in this stream are directly mappable (i.e. primitive or non-pointer types) then
-	// Distinct is used as the result, otherwise returns an empty stream
+	// if provided comparator is nil, Distinct is used as the result
 	Unique(c Comparator[T]) Stream[T]
 	// AsSlice returns the underlying slice
 	AsSlice() []T
@@ -191,9 +208,12 @@ func (s *stream[T]) AnyMatch(p Predicate[T]) bool {
 
 // Append creates a new stream with all the elements of this stream followed by the specified elements
 func (s *stream[T]) Append(items ...T) Stream[T] {
-	return &stream[T]{
-		elements: append(s.elements, items...),
+	r := &stream[T]{
+		elements: make([]T, 0, len(s.elements)+len(items)),
 	}
+	r.elements = append(r.elements, s.elements...)
+	r.elements = append(r.elements, items...)
+	return r
 }
 
 // AsSlice returns the underlying slice
@@ -252,16 +272,12 @@ func (s *stream[T]) Difference(other Stream[T], c Comparator[T]) Stream[T] {
 }
 
 // Distinct creates a new stream of distinct elements in this stream
+//
+// elements whose values are not hashable (e.g. slices, maps or funcs) are compared using reflect.DeepEqual
 func (s *stream[T]) Distinct() Stream[T] {
-	dvs := map[any]bool{}
-	r := &stream[T]{}
-	for _, v := range s.elements {
-		if !dvs[v] {
-			dvs[v] = true
-			r.elements = append(r.elements, v)
-		}
+	return &stream[T]{
+		elements: distinctElements(s.elements),
 	}
-	return r
 }
 
 // Filter creates a new stream of elements in this stream that match the provided predicate
@@ -512,6 +528,39 @@ func (s *stream[T]) NthMatch(p Predicate[T], nth int) *gopt.Optional[T] {
 	return gopt.Empty[T]()
 }
 
+// Page returns the nth page (1 based) of elements of this stream, where each page has the specified size - along with
+// page metadata (total elements, total pages and whether there are next/previous pages)
+//
+// if the page number is out of range or the size is less than 1, the page elements are empty
+func (s *stream[T]) Page(n int, size int) Page[T] {
+	return newPage(s.elements, n, size)
+}
+
+// Paginate returns an iterator over the pages of this stream, where each page has the specified page size
+//
+// if the page size is less than 1, there are no pages
+func (s *stream[T]) Paginate(pageSize int) Pages[T] {
+	return newPages(s.elements, pageSize)
+}
+
+// Peek creates a new stream with the same elements as this stream, additionally performing the provided action on each element
+//
+// Peek is intended for debugging - for example, to see elements as they flow through a chain of operations
+//
+// any error returned by the consumer is ignored, and if the provided consumer is nil, nothing is performed
+func (s *stream[T]) Peek(c Consumer[T]) Stream[T] {
+	r := &stream[T]{
+		elements: make([]T, 0, len(s.elements)),
+	}
+	for _, v := range s.elements {
+		if c != nil {
+			_ = c.Accept(v)
+		}
+		r.elements = append(r.elements, v)
+	}
+	return r
+}
+
 // Reverse creates a new stream composed of elements from this stream but in reverse order
 func (s *stream[T]) Reverse() Stream[T] {
 	l := len(s.elements)
@@ -564,18 +613,8 @@ func (s *stream[T]) Slice(start int, count int) Stream[T] {
 // Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
 //
 // if the provided comparator is nil, the elements are not sorted
-func (s *stream[T]) Sorted(c Comparator[T]) Stream[T] {
-	es := make([]T, 0, len(s.elements))
-	es = append(es, s.elements...)
-	r := &stream[T]{
-		elements: es,
-	}
-	if c != nil {
-		sort.Slice(r.elements, func(i, j int) bool {
-			return c.Less(r.elements[i], r.elements[j])
-		})
-	}
-	return r
+func (s *stream[T]) Sorted(c Comparator[T]) SortedStream[T] {
+	return newSortedStream[T](s.elements, c)
 }
 
 // SymmetricDifference creates a new stream that is the set symmetric difference between this and the supplied other stream
@@ -610,26 +649,23 @@ func (s *stream[T]) Union(other Stream[T], c Comparator[T]) Stream[T] {
 //
 // uniqueness is determined using the provided comparator
 //
-// if provided comparator is nil but the value type of elements in this stream are directly mappable (i.e. primitive or non-pointer types) then
-// Distinct is used as the result, otherwise returns an empty stream
+// if provided comparator is nil, Distinct is used as the result
 func (s *stream[T]) Unique(c Comparator[T]) Stream[T] {
+	if c == nil {
+		return s.Distinct()
+	}
 	r := &stream[T]{}
-	var vt T
-	if c != nil {
-		pres := make([]bool, len(s.elements))
-		for i, v := range s.elements {
-			if !pres[i] {
-				for j := i + 1; j < len(s.elements); j++ {
-					if !pres[j] && c.Compare(v, s.elements[j]) == 0 {
-						pres[j] = true
-					}
+	pres := make([]bool, len(s.elements))
+	for i, v := range s.elements {
+		if !pres[i] {
+			for j := i + 1; j < len(s.elements); j++ {
+				if !pres[j] && c.Compare(v, s.elements[j]) == 0 {
+					pres[j] = true
 				}
-				pres[i] = true
-				r.elements = append(r.elements, v)
 			}
+			pres[i] = true
+			r.elements = append(r.elements, v)
 		}
-	} else if isDistinctable(vt) {
-		return s.Distinct()
 	}
 	return r
 }
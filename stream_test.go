@@ -60,6 +60,14 @@ func TestStream_Append(t *testing.T) {
 	s := Of("a", "b", "c")
 	s2 := s.Append("d", "e", "f")
 	require.Equal(t, 6, s2.Len())
+
+	// appends from the same parent do not overwrite each other...
+	base := s.Limit(2)
+	s3 := base.Append("x")
+	s4 := base.Append("y")
+	require.Equal(t, []string{"a", "b", "x"}, s3.AsSlice())
+	require.Equal(t, []string{"a", "b", "y"}, s4.AsSlice())
+	require.Equal(t, []string{"a", "b", "c"}, s.AsSlice())
 }
 
 func TestStream_AsSlice(t *testing.T) {
@@ -396,6 +404,41 @@ func TestStream_NthMatch(t *testing.T) {
 	require.False(t, o.IsPresent())
 }
 
+func TestStream_Page(t *testing.T) {
+	s := Of("a", "b", "c", "d", "e")
+	p := s.Page(2, 2)
+	require.Equal(t, []string{"c", "d"}, p.Elements.AsSlice())
+	require.Equal(t, 2, p.Number)
+	require.Equal(t, 5, p.Total)
+	require.Equal(t, 3, p.TotalPages)
+	require.True(t, p.HasNext)
+	require.True(t, p.HasPrevious)
+}
+
+func TestStream_Paginate(t *testing.T) {
+	s := Of("a", "b", "c", "d", "e")
+	pages := s.Paginate(2)
+	require.Equal(t, 3, pages.Len())
+	collected := make([][]string, 0)
+	for page, ok := pages.Next(); ok; page, ok = pages.Next() {
+		collected = append(collected, page.Elements.AsSlice())
+	}
+	require.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, collected)
+}
+
+func TestStream_Peek(t *testing.T) {
+	s := Of("a", "b", "c")
+	peeked := make([]string, 0)
+	s2 := s.Peek(NewConsumer(func(v string) error {
+		peeked = append(peeked, v)
+		return errors.New("ignored")
+	}))
+	require.Equal(t, []string{"a", "b", "c"}, peeked)
+	require.Equal(t, []string{"a", "b", "c"}, s2.AsSlice())
+	s2 = s.Peek(nil)
+	require.Equal(t, 3, s2.Len())
+}
+
 func TestStream_Reverse(t *testing.T) {
 	s := Of("1", "2", "3", "4", "5", "6", "7", "8", "9", "10")
 	s2 := s.Reverse()
@@ -471,13 +514,13 @@ func TestStream_Sorted(t *testing.T) {
 	})
 	s2 := s.Sorted(c)
 	require.Equal(t, 10, s2.Len())
-	rs2, ok := s2.(*stream[string])
+	rs2, ok := s2.(*sortedStream[string])
 	require.True(t, ok)
 	require.Equal(t, "a", rs2.elements[0])
 
 	s2 = s.Sorted(nil)
 	require.Equal(t, 10, s2.Len())
-	rs2, ok = s2.(*stream[string])
+	rs2, ok = s2.(*sortedStream[string])
 	require.True(t, ok)
 	require.Equal(t, "d", rs2.elements[0])
 }
@@ -561,6 +604,7 @@ func TestStream_Unique(t *testing.T) {
 		return 0
 	}))
 	require.Equal(t, 3, s3.Len())
+	// nil comparator uses Distinct - distinct pointers...
 	s3 = s3.Unique(nil)
-	require.Equal(t, 0, s3.Len())
+	require.Equal(t, 3, s3.Len())
 }
@@ -2,7 +2,6 @@ package streams
 
 import (
 	"github.com/go-andiamo/gopt"
-	"sort"
 )
 
 // Streamable is a type alias that provides a Stream interface around a slice
@@ -39,9 +38,12 @@ func (s Streamable[T]) AnyMatch(p Predicate[T]) bool {
 
 // Append creates a new stream with all the elements of this stream followed by the specified elements
 func (s Streamable[T]) Append(items ...T) Stream[T] {
-	return &stream[T]{
-		elements: append(s, items...),
+	r := &stream[T]{
+		elements: make([]T, 0, len(s)+len(items)),
 	}
+	r.elements = append(r.elements, s...)
+	r.elements = append(r.elements, items...)
+	return r
 }
 
 // AsSlice returns the underlying slice
@@ -100,16 +102,12 @@ func (s Streamable[T]) Difference(other Stream[T], c Comparator[T]) Stream[T] {
 }
 
 // Distinct creates a new stream of distinct elements in this stream
+//
+// elements whose values are not hashable (e.g. slices, maps or funcs) are compared using reflect.DeepEqual
 func (s Streamable[T]) Distinct() Stream[T] {
-	dvs := map[any]bool{}
-	r := &stream[T]{}
-	for _, v := range s {
-		if !dvs[v] {
-			dvs[v] = true
-			r.elements = append(r.elements, v)
-		}
+	return &stream[T]{
+		elements: distinctElements(s),
 	}
-	return r
 }
 
 // Filter creates a new stream of elements in this stream that match the provided predicate
@@ -360,6 +358,39 @@ func (s Streamable[T]) NthMatch(p Predicate[T], nth int) *gopt.Optional[T] {
 	return gopt.Empty[T]()
 }
 
+// Page returns the nth page (1 based) of elements of this stream, where each page has the specified size - along with
+// page metadata (total elements, total pages and whether there are next/previous pages)
+//
+// if the page number is out of range or the size is less than 1, the page elements are empty
+func (s Streamable[T]) Page(n int, size int) Page[T] {
+	return newPage(s, n, size)
+}
+
+// Paginate returns an iterator over the pages of this stream, where each page has the specified page size
+//
+// if the page size is less than 1, there are no pages
+func (s Streamable[T]) Paginate(pageSize int) Pages[T] {
+	return newPages(append(make([]T, 0, len(s)), s...), pageSize)
+}
+
+// Peek creates a new stream with the same elements as this stream, additionally performing the provided action on each element
+//
+// Peek is intended for debugging - for example, to see elements as they flow through a chain of operations
+//
+// any error returned by the consumer is ignored, and if the provided consumer is nil, nothing is performed
+func (s Streamable[T]) Peek(c Consumer[T]) Stream[T] {
+	r := &stream[T]{
+		elements: make([]T, 0, len(s)),
+	}
+	for _, v := range s {
+		if c != nil {
+			_ = c.Accept(v)
+		}
+		r.elements = append(r.elements, v)
+	}
+	return r
+}
+
 // Reverse creates a new stream composed of elements from this stream but in reverse order
 func (s Streamable[T]) Reverse() Stream[T] {
 	l := len(s)
@@ -412,18 +443,8 @@ func (s Streamable[T]) Slice(start int, count int) Stream[T] {
 // Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
 //
 // if the provided comparator is nil, the elements are not sorted
-func (s Streamable[T]) Sorted(c Comparator[T]) Stream[T] {
-	es := make([]T, 0, len(s))
-	es = append(es, s...)
-	r := &stream[T]{
-		elements: es,
-	}
-	if c != nil {
-		sort.Slice(r.elements, func(i, j int) bool {
-			return c.Less(r.elements[i], r.elements[j])
-		})
-	}
-	return r
+func (s Streamable[T]) Sorted(c Comparator[T]) SortedStream[T] {
+	return newSortedStream[T](s, c)
 }
 
 // SymmetricDifference creates a new stream that is the set symmetric difference between this and the supplied other stream
@@ -458,26 +479,23 @@ func (s Streamable[T]) Union(other Stream[T], c Comparator[T]) Stream[T] {
 //
 // uniqueness is determined using the provided comparator
 //
-// if provided comparator is nil but the value type of elements in this stream are directly mappable (i.e. primitive or non-pointer types) then
-// Distinct is used as the result, otherwise returns an empty stream
+// if provided comparator is nil, Distinct is used as the result
 func (s Streamable[T]) Unique(c Comparator[T]) Stream[T] {
+	if c == nil {
+		return s.Distinct()
+	}
 	r := &stream[T]{}
-	var vt T
-	if c != nil {
-		pres := make([]bool, len(s))
-		for i, v := range s {
-			if !pres[i] {
-				for j := i + 1; j < len(s); j++ {
-					if !pres[j] && c.Compare(v, s[j]) == 0 {
-						pres[j] = true
-					}
+	pres := make([]bool, len(s))
+	for i, v := range s {
+		if !pres[i] {
+			for j := i + 1; j < len(s); j++ {
+				if !pres[j] && c.Compare(v, s[j]) == 0 {
+					pres[j] = true
 				}
-				pres[i] = true
-				r.elements = append(r.elements, v)
 			}
+			pres[i] = true
+			r.elements = append(r.elements, v)
 		}
-	} else if isDistinctable(vt) {
-		return s.Distinct()
 	}
 	return r
 }
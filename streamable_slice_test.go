@@ -53,6 +53,13 @@ func TestStreamableSlice_Append(t *testing.T) {
 	s := NewStreamableSlice(&[]string{"a", "b", "c"})
 	s2 := s.Append("d", "e", "f")
 	require.Equal(t, 6, s2.Len())
+
+	sl := make([]string, 0, 10)
+	s = NewStreamableSlice(&sl)
+	s3 := s.Append("x")
+	s4 := s.Append("y")
+	require.Equal(t, []string{"x"}, s3.AsSlice())
+	require.Equal(t, []string{"y"}, s4.AsSlice())
 }
 
 func TestStreamableSlice_AsSlice(t *testing.T) {
@@ -385,6 +392,34 @@ func TestStreamableSlice_NthMatch(t *testing.T) {
 	require.False(t, o.IsPresent())
 }
 
+func TestStreamableSlice_Page(t *testing.T) {
+	sl := []string{"a", "b", "c"}
+	s := NewStreamableSlice(&sl)
+	p := s.Page(1, 2)
+	require.Equal(t, []string{"a", "b"}, p.Elements.AsSlice())
+	require.True(t, p.HasNext)
+	pages := s.Paginate(2)
+	sl[0] = "z"
+	// page elements and pages are not affected by changes to the underlying slice...
+	require.Equal(t, []string{"a", "b"}, p.Elements.AsSlice())
+	first, ok := pages.Next()
+	require.True(t, ok)
+	require.Equal(t, []string{"a", "b"}, first.Elements.AsSlice())
+}
+
+func TestStreamableSlice_Peek(t *testing.T) {
+	s := NewStreamableSlice(&[]string{"a", "b", "c"})
+	peeked := make([]string, 0)
+	s2 := s.Peek(NewConsumer(func(v string) error {
+		peeked = append(peeked, v)
+		return errors.New("ignored")
+	}))
+	require.Equal(t, []string{"a", "b", "c"}, peeked)
+	require.Equal(t, []string{"a", "b", "c"}, s2.AsSlice())
+	s2 = s.Peek(nil)
+	require.Equal(t, 3, s2.Len())
+}
+
 func TestStreamableSlice_Reverse(t *testing.T) {
 	s := NewStreamableSlice(&[]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"})
 	s2 := s.Reverse()
@@ -460,13 +495,13 @@ func TestStreamableSlice_Sorted(t *testing.T) {
 	})
 	s2 := s.Sorted(c)
 	require.Equal(t, 10, s2.Len())
-	rs2, ok := s2.(*stream[string])
+	rs2, ok := s2.(*sortedStream[string])
 	require.True(t, ok)
 	require.Equal(t, "a", rs2.elements[0])
 
 	s2 = s.Sorted(nil)
 	require.Equal(t, 10, s2.Len())
-	rs2, ok = s2.(*stream[string])
+	rs2, ok = s2.(*sortedStream[string])
 	require.True(t, ok)
 	require.Equal(t, "d", rs2.elements[0])
 }
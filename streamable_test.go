@@ -72,6 +72,12 @@ func TestStreamable_Append(t *testing.T) {
 	s := Streamable[string](sl)
 	s2 := s.Append("d", "e", "f")
 	require.Equal(t, 6, s2.Len())
+
+	s = Streamable[string](make([]string, 0, 10))
+	s3 := s.Append("x")
+	s4 := s.Append("y")
+	require.Equal(t, []string{"x"}, s3.AsSlice())
+	require.Equal(t, []string{"y"}, s4.AsSlice())
 }
 
 func TestStreamable_AsSlice(t *testing.T) {
@@ -420,6 +426,33 @@ func TestStreamable_NthMatch(t *testing.T) {
 	require.False(t, o.IsPresent())
 }
 
+func TestStreamable_Page(t *testing.T) {
+	s := Streamable[string]([]string{"a", "b", "c"})
+	p := s.Page(2, 2)
+	require.Equal(t, []string{"c"}, p.Elements.AsSlice())
+	require.False(t, p.HasNext)
+	require.Equal(t, 2, s.Paginate(2).Len())
+	pages := s.Paginate(2)
+	s[0] = "z"
+	// pages are not affected by changes to the underlying slice...
+	first, ok := pages.Next()
+	require.True(t, ok)
+	require.Equal(t, []string{"a", "b"}, first.Elements.AsSlice())
+}
+
+func TestStreamable_Peek(t *testing.T) {
+	s := Streamable[string]([]string{"a", "b", "c"})
+	peeked := make([]string, 0)
+	s2 := s.Peek(NewConsumer(func(v string) error {
+		peeked = append(peeked, v)
+		return errors.New("ignored")
+	}))
+	require.Equal(t, []string{"a", "b", "c"}, peeked)
+	require.Equal(t, []string{"a", "b", "c"}, s2.AsSlice())
+	s2 = s.Peek(nil)
+	require.Equal(t, 3, s2.Len())
+}
+
 func TestStreamable_Reverse(t *testing.T) {
 	sl := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
 	s := Streamable[string](sl)
@@ -500,13 +533,13 @@ func TestStreamable_Sorted(t *testing.T) {
 	})
 	s2 := s.Sorted(c)
 	require.Equal(t, 10, s2.Len())
-	rs2, ok := s2.(*stream[string])
+	rs2, ok := s2.(*sortedStream[string])
 	require.True(t, ok)
 	require.Equal(t, "a", rs2.elements[0])
 
 	s2 = s.Sorted(nil)
 	require.Equal(t, 10, s2.Len())
-	rs2, ok = s2.(*stream[string])
+	rs2, ok = s2.(*sortedStream[string])
 	require.True(t, ok)
 	require.Equal(t, "d", rs2.elements[0])
 }
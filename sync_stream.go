@@ -0,0 +1,351 @@
+package streams
+
+import (
+	"github.com/go-andiamo/gopt"
+	"sync"
+)
+
+// ConcurrentStream is a Stream that is safe for concurrent use
+//
+// read operations are performed on a snapshot of the elements (taken under a read lock) - so any predicates, comparators
+// or consumers provided to the read operations are called without the lock being held
+//
+// the elements can be safely changed using the Add, RemoveIf and Replace mutators
+type ConcurrentStream[T any] interface {
+	Stream[T]
+	// Add appends the specified items to the elements of this stream
+	Add(items ...T)
+	// RemoveIf removes all elements of this stream that match the provided predicate - returning the number of elements removed
+	//
+	// if the provided predicate is nil, nothing is removed
+	RemoveIf(p Predicate[T]) int
+	// Replace replaces all the elements of this stream with the specified items
+	Replace(items ...T)
+}
+
+// NewConcurrentStream creates a new ConcurrentStream, initialised with a copy of the specified values
+//
+// the elements are owned by the stream and guarded by its own sync.RWMutex
+func NewConcurrentStream[T any](values ...T) ConcurrentStream[T] {
+	elements := make([]T, len(values))
+	copy(elements, values)
+	return NewSyncStreamableSlice[T](&elements, nil)
+}
+
+// NewSyncStreamableSlice creates a ConcurrentStream from a pointer to a slice - where access to the slice is guarded
+// by the provided lock
+//
+// As with NewStreamableSlice, if the underlying slice changes, so does the stream - but other code changing the
+// underlying slice must also hold the provided lock while doing so
+//
+// if the provided lock is a sync.RWMutex (or anything else that provides an RLocker() method), read operations use
+// the read lock
+//
+// if the provided lock is nil, a new sync.RWMutex is used (and the slice should then only be changed via the stream)
+func NewSyncStreamableSlice[T any](sl *[]T, lock sync.Locker) ConcurrentStream[T] {
+	if lock == nil {
+		lock = &sync.RWMutex{}
+	}
+	rlock := lock
+	if rl, ok := lock.(interface{ RLocker() sync.Locker }); ok {
+		rlock = rl.RLocker()
+	}
+	return &syncStream[T]{
+		elements: sl,
+		lock:     lock,
+		rlock:    rlock,
+	}
+}
+
+type syncStream[T any] struct {
+	elements *[]T
+	lock     sync.Locker
+	rlock    sync.Locker
+}
+
+// snapshot returns a stream of a copy of the current elements (copied under the read lock)
+func (s *syncStream[T]) snapshot() *stream[T] {
+	s.rlock.Lock()
+	defer s.rlock.Unlock()
+	es := make([]T, len(*s.elements))
+	copy(es, *s.elements)
+	return &stream[T]{
+		elements: es,
+	}
+}
+
+// Add appends the specified items to the elements of this stream
+func (s *syncStream[T]) Add(items ...T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	*s.elements = append(*s.elements, items...)
+}
+
+// RemoveIf removes all elements of this stream that match the provided predicate - returning the number of elements removed
+//
+// if the provided predicate is nil, nothing is removed
+//
+// Note: the predicate is called whilst the write lock is held - so must not call back into this stream
+func (s *syncStream[T]) RemoveIf(p Predicate[T]) int {
+	if p == nil {
+		return 0
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return removeIf(s.elements, p)
+}
+
+// Replace replaces all the elements of this stream with the specified items
+func (s *syncStream[T]) Replace(items ...T) {
+	es := make([]T, len(items))
+	copy(es, items)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	*s.elements = es
+}
+
+// AsSlice returns a copy of the underlying slice
+func (s *syncStream[T]) AsSlice() []T {
+	return s.snapshot().elements
+}
+
+// Len returns the length (number of elements) of this stream
+func (s *syncStream[T]) Len() int {
+	s.rlock.Lock()
+	defer s.rlock.Unlock()
+	return len(*s.elements)
+}
+
+// AllMatch returns whether all elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *syncStream[T]) AllMatch(p Predicate[T]) bool {
+	return s.snapshot().AllMatch(p)
+}
+
+// AnyMatch returns whether any elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *syncStream[T]) AnyMatch(p Predicate[T]) bool {
+	return s.snapshot().AnyMatch(p)
+}
+
+// Append creates a new stream with all the elements of this stream followed by the specified elements
+func (s *syncStream[T]) Append(items ...T) Stream[T] {
+	return s.snapshot().Append(items...)
+}
+
+// Concat creates a new stream with all the elements of this stream followed by all the elements of the added stream
+func (s *syncStream[T]) Concat(add Stream[T]) Stream[T] {
+	return s.snapshot().Concat(add)
+}
+
+// Count returns the count of elements that match the provided predicate
+//
+// If the predicate is nil, returns the count of all elements
+func (s *syncStream[T]) Count(p Predicate[T]) int {
+	return s.snapshot().Count(p)
+}
+
+// Difference creates a new stream that is the set difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *syncStream[T]) Difference(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.snapshot().Difference(other, c)
+}
+
+// Distinct creates a new stream of distinct elements in this stream
+//
+// elements whose values are not hashable (e.g. slices, maps or funcs) are compared using reflect.DeepEqual
+func (s *syncStream[T]) Distinct() Stream[T] {
+	return s.snapshot().Distinct()
+}
+
+// Filter creates a new stream of elements in this stream that match the provided predicate
+//
+// if the provided predicate is nil, all elements in this stream are returned
+func (s *syncStream[T]) Filter(p Predicate[T]) Stream[T] {
+	return s.snapshot().Filter(p)
+}
+
+// FirstMatch returns an optional of the first element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the first element in this stream is returned
+func (s *syncStream[T]) FirstMatch(p Predicate[T]) *gopt.Optional[T] {
+	return s.snapshot().FirstMatch(p)
+}
+
+// ForEach performs an action on each element of this stream
+//
+// the action to be performed is defined by the provided consumer
+//
+// if the provided consumer is nil, nothing is performed
+func (s *syncStream[T]) ForEach(c Consumer[T]) error {
+	return s.snapshot().ForEach(c)
+}
+
+// Has returns whether this stream contains an element that is equal to the element value provided
+//
+// equality is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false
+func (s *syncStream[T]) Has(v T, c Comparator[T]) bool {
+	return s.snapshot().Has(v, c)
+}
+
+// Intersection creates a new stream that is the set intersection of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *syncStream[T]) Intersection(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.snapshot().Intersection(other, c)
+}
+
+// Iterator returns an iterator (pull) function
+//
+// the iterator function can be used in for loops, for example
+//  next := strm.Iterator()
+//  for v, ok := next(); ok; v, ok = next() {
+//      fmt.Println(v)
+//  }
+//
+// Iterator can also optionally accept varargs of Predicate - which, if specified, are logically OR-ed on each pull to ensure
+// that pulled elements match
+func (s *syncStream[T]) Iterator(ps ...Predicate[T]) func() (T, bool) {
+	return s.snapshot().Iterator(ps...)
+}
+
+// LastMatch returns an optional of the last element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the last element in this stream is returned
+func (s *syncStream[T]) LastMatch(p Predicate[T]) *gopt.Optional[T] {
+	return s.snapshot().LastMatch(p)
+}
+
+// Limit creates a new stream whose number of elements is limited to the value provided
+//
+// if the maximum size is greater than the length of this stream, all elements are returned
+func (s *syncStream[T]) Limit(maxSize int) Stream[T] {
+	return s.snapshot().Limit(maxSize)
+}
+
+// Max returns the maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *syncStream[T]) Max(c Comparator[T]) *gopt.Optional[T] {
+	return s.snapshot().Max(c)
+}
+
+// Min returns the minimum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *syncStream[T]) Min(c Comparator[T]) *gopt.Optional[T] {
+	return s.snapshot().Min(c)
+}
+
+// MinMax returns the minimum and maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned for both
+func (s *syncStream[T]) MinMax(c Comparator[T]) (*gopt.Optional[T], *gopt.Optional[T]) {
+	return s.snapshot().MinMax(c)
+}
+
+// NoneMatch returns whether none of the elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns true
+func (s *syncStream[T]) NoneMatch(p Predicate[T]) bool {
+	return s.snapshot().NoneMatch(p)
+}
+
+// NthMatch returns an optional of the nth matching element (1 based) according to the provided predicate
+//
+// if the nth argument is negative, the nth is taken as relative to the last
+//
+// if the provided predicate is nil, any element is taken as matching
+//
+// if no elements match in the specified position, an empty (not present) optional is returned
+func (s *syncStream[T]) NthMatch(p Predicate[T], nth int) *gopt.Optional[T] {
+	return s.snapshot().NthMatch(p, nth)
+}
+
+// Page returns the nth page (1 based) of elements of this stream, where each page has the specified size - along with
+// page metadata (total elements, total pages and whether there are next/previous pages)
+//
+// if the page number is out of range or the size is less than 1, the page elements are empty
+func (s *syncStream[T]) Page(n int, size int) Page[T] {
+	s.rlock.Lock()
+	defer s.rlock.Unlock()
+	return newPage(*s.elements, n, size)
+}
+
+// Paginate returns an iterator over the pages of this stream, where each page has the specified page size
+//
+// if the page size is less than 1, there are no pages
+func (s *syncStream[T]) Paginate(pageSize int) Pages[T] {
+	return newPages(s.snapshot().AsSlice(), pageSize)
+}
+
+// Peek creates a new stream with the same elements as this stream, additionally performing the provided action on each element
+//
+// Peek is intended for debugging - for example, to see elements as they flow through a chain of operations
+//
+// any error returned by the consumer is ignored, and if the provided consumer is nil, nothing is performed
+func (s *syncStream[T]) Peek(c Consumer[T]) Stream[T] {
+	return s.snapshot().Peek(c)
+}
+
+// Reverse creates a new stream composed of elements from this stream but in reverse order
+func (s *syncStream[T]) Reverse() Stream[T] {
+	return s.snapshot().Reverse()
+}
+
+// Skip creates a new stream consisting of this stream after discarding the first n elements
+//
+// if the specified n to skip is equal to or greater than the number of elements in this stream,
+// an empty stream is returned
+func (s *syncStream[T]) Skip(n int) Stream[T] {
+	return s.snapshot().Skip(n)
+}
+
+// Slice creates a new stream composed of elements from this stream starting at the specified start and including
+// the specified count (or to the end)
+//
+// the start is zero based (and less than zero is ignored)
+//
+// if the specified count is negative, items are selected from the start and then backwards by the count
+func (s *syncStream[T]) Slice(start int, count int) Stream[T] {
+	return s.snapshot().Slice(start, count)
+}
+
+// Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
+//
+// if the provided comparator is nil, the elements are not sorted
+func (s *syncStream[T]) Sorted(c Comparator[T]) SortedStream[T] {
+	return s.snapshot().Sorted(c)
+}
+
+// SymmetricDifference creates a new stream that is the set symmetric difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *syncStream[T]) SymmetricDifference(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.snapshot().SymmetricDifference(other, c)
+}
+
+// Union creates a new stream that is the set union of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *syncStream[T]) Union(other Stream[T], c Comparator[T]) Stream[T] {
+	return s.snapshot().Union(other, c)
+}
+
+// Unique creates a new stream of unique elements in this stream
+//
+// uniqueness is determined using the provided comparator
+//
+// if provided comparator is nil, Distinct is used as the result
+func (s *syncStream[T]) Unique(c Comparator[T]) Stream[T] {
+	return s.snapshot().Unique(c)
+}
@@ -0,0 +1,192 @@
+package streams
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewConcurrentStream(t *testing.T) {
+	values := []string{"a", "b", "c"}
+	s := NewConcurrentStream(values...)
+	require.Equal(t, 3, s.Len())
+	values[0] = "z"
+	require.Equal(t, []string{"a", "b", "c"}, s.AsSlice())
+	ss, ok := s.(*syncStream[string])
+	require.True(t, ok)
+	_, ok = ss.lock.(*sync.RWMutex)
+	require.True(t, ok)
+	require.NotEqual(t, ss.lock, ss.rlock)
+}
+
+func TestNewSyncStreamableSlice(t *testing.T) {
+	sl := &[]string{"a", "b", "c"}
+	mutex := &sync.Mutex{}
+	s := NewSyncStreamableSlice(sl, mutex)
+	require.Equal(t, 3, s.Len())
+	mutex.Lock()
+	*sl = append(*sl, "d")
+	mutex.Unlock()
+	require.Equal(t, 4, s.Len())
+	ss := s.(*syncStream[string])
+	require.Equal(t, ss.lock, ss.rlock)
+}
+
+func TestSyncStream_AsSlice(t *testing.T) {
+	sl := &[]string{"a", "b", "c"}
+	s := NewSyncStreamableSlice(sl, nil)
+	as := s.AsSlice()
+	require.Equal(t, []string{"a", "b", "c"}, as)
+	as[0] = "z"
+	require.Equal(t, "a", (*sl)[0])
+}
+
+func TestSyncStream_Add(t *testing.T) {
+	sl := &[]string{"a"}
+	s := NewSyncStreamableSlice(sl, nil)
+	s.Add("b", "c")
+	require.Equal(t, []string{"a", "b", "c"}, *sl)
+	require.Equal(t, 3, s.Len())
+}
+
+func TestSyncStream_RemoveIf(t *testing.T) {
+	sl := &[]string{"a", "B", "c", "D"}
+	s := NewSyncStreamableSlice(sl, nil)
+	require.Equal(t, 0, s.RemoveIf(nil))
+	n := s.RemoveIf(NewPredicate(func(v string) bool {
+		return strings.ToUpper(v) == v
+	}))
+	require.Equal(t, 2, n)
+	require.Equal(t, []string{"a", "c"}, *sl)
+}
+
+func TestSyncStream_Replace(t *testing.T) {
+	sl := &[]string{"a", "b"}
+	s := NewSyncStreamableSlice(sl, nil)
+	items := []string{"x", "y", "z"}
+	s.Replace(items...)
+	items[0] = "changed"
+	require.Equal(t, []string{"x", "y", "z"}, *sl)
+	s.Replace()
+	require.Equal(t, 0, s.Len())
+}
+
+func TestSyncStream_ReadsSnapshot(t *testing.T) {
+	s := NewConcurrentStream("a", "b", "c")
+	// the consumer is called without the lock being held - so can call mutators...
+	err := s.ForEach(NewConsumer(func(v string) error {
+		s.Add(v + v)
+		return nil
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c", "aa", "bb", "cc"}, s.AsSlice())
+	f := s.Filter(NewPredicate(func(v string) bool {
+		return len(v) == 1
+	}))
+	s.Replace()
+	require.Equal(t, 3, f.Len())
+}
+
+func TestSyncStream_ReadsCallbacksWithoutLock(t *testing.T) {
+	s := NewConcurrentStream("a", "b", "c")
+	// predicates and comparators are called without the lock being held - so can call back into the stream...
+	p := NewPredicate(func(v string) bool {
+		s.Add(v + v)
+		return s.Len() > 0
+	})
+	c := NewComparator(func(v1, v2 string) int {
+		_ = s.Has(v1, StringComparator)
+		return StringComparator.Compare(v1, v2)
+	})
+	require.True(t, s.AllMatch(p))
+	require.True(t, s.AnyMatch(p))
+	require.Equal(t, 0, s.Count(p.Negate()))
+	require.True(t, s.FirstMatch(p).IsPresent())
+	require.True(t, s.LastMatch(p).IsPresent())
+	require.False(t, s.NoneMatch(p))
+	require.True(t, s.NthMatch(p, 1).IsPresent())
+	require.True(t, s.Has("a", c))
+	require.True(t, s.Max(c).IsPresent())
+	require.True(t, s.Min(c).IsPresent())
+	mn, mx := s.MinMax(c)
+	require.True(t, mn.IsPresent())
+	require.True(t, mx.IsPresent())
+}
+
+func TestSyncStream_ReadOperations(t *testing.T) {
+	s := NewConcurrentStream("c", "a", "b", "a")
+	upper := NewPredicate(func(v string) bool {
+		return strings.ToUpper(v) == v
+	})
+	isA := NewPredicate(func(v string) bool {
+		return v == "a"
+	})
+	require.False(t, s.AllMatch(upper))
+	require.False(t, s.AnyMatch(upper))
+	require.True(t, s.NoneMatch(upper))
+	require.Equal(t, 5, s.Append("d").Len())
+	require.Equal(t, 6, s.Concat(Of("x", "y")).Len())
+	require.Equal(t, 2, s.Count(isA))
+	require.Equal(t, []string{"c", "b"}, s.Difference(Of("a"), StringComparator).AsSlice())
+	require.Equal(t, []string{"c", "a", "b"}, s.Distinct().AsSlice())
+	require.Equal(t, "c", s.FirstMatch(nil).OrElse(""))
+	require.True(t, s.Has("b", StringComparator))
+	require.Equal(t, []string{"a", "a"}, s.Intersection(Of("a"), StringComparator).AsSlice())
+	next := s.Iterator(isA)
+	v, ok := next()
+	require.True(t, ok)
+	require.Equal(t, "a", v)
+	require.Equal(t, "a", s.LastMatch(nil).OrElse(""))
+	require.Equal(t, 2, s.Limit(2).Len())
+	require.Equal(t, "c", s.Max(StringComparator).OrElse(""))
+	require.Equal(t, "a", s.Min(StringComparator).OrElse(""))
+	mn, mx := s.MinMax(StringComparator)
+	require.Equal(t, "a", mn.OrElse(""))
+	require.Equal(t, "c", mx.OrElse(""))
+	require.Equal(t, "b", s.NthMatch(nil, 3).OrElse(""))
+	require.Equal(t, []string{"b", "a"}, s.Page(2, 2).Elements.AsSlice())
+	require.Equal(t, 2, s.Paginate(3).Len())
+	peeked := 0
+	require.Equal(t, 4, s.Peek(NewConsumer(func(v string) error {
+		peeked++
+		return nil
+	})).Len())
+	require.Equal(t, 4, peeked)
+	require.Equal(t, []string{"a", "b", "a", "c"}, s.Reverse().AsSlice())
+	require.Equal(t, []string{"b", "a"}, s.Skip(2).AsSlice())
+	require.Equal(t, []string{"a", "b"}, s.Slice(1, 2).AsSlice())
+	require.Equal(t, []string{"a", "a", "b", "c"}, s.Sorted(StringComparator).AsSlice())
+	require.Equal(t, []string{"c", "b", "z"}, s.SymmetricDifference(Of("a", "z"), StringComparator).AsSlice())
+	require.Equal(t, 5, s.Union(Of("z"), StringComparator).Len())
+	require.Equal(t, 3, s.Unique(StringComparator).Len())
+}
+
+func TestSyncStream_ConcurrentAccess(t *testing.T) {
+	s := NewConcurrentStream[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Add(i*100 + j)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = s.Filter(NewPredicate(func(v int) bool {
+					return v%2 == 0
+				})).Len()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1000, s.Len())
+	n := s.RemoveIf(NewPredicate(func(v int) bool {
+		return v%2 == 0
+	}))
+	require.Equal(t, 500, n)
+	require.Equal(t, 500, s.Len())
+}
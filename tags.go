@@ -0,0 +1,132 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TagName is the struct tag name used to declare sorting and filtering behaviour of fields - for example
+//  type Issue struct {
+//      Title    string `streams:"sort=asc,filterable,ci"`
+//      Priority int    `streams:"sort=desc,nulls=first,filterable"`
+//  }
+//
+// tag options are...
+//   - sort (or sort=asc / sort=desc) - the field is sortable (see ComparatorFor) in the default direction specified
+//   - nulls=first / nulls=last - the placement of null values when sorting (the default is last)
+//   - filterable - the field can be filtered on (see PredicateFor)
+//   - ci - string comparisons (for both sorting and filtering) are case-insensitive
+const TagName = "streams"
+
+var (
+	// ErrFieldNotSortable is the error (wrapped) returned by ComparatorFor when the field is not tagged as sortable
+	ErrFieldNotSortable = errors.New("field is not sortable")
+	// ErrFieldNotFilterable is the error (wrapped) returned by PredicateFor when the field is not tagged as filterable
+	ErrFieldNotFilterable = errors.New("field is not filterable")
+)
+
+type fieldTag struct {
+	sortable   bool
+	descending bool
+	nullsFirst bool
+	filterable bool
+	ci         bool
+}
+
+func parseFieldTag(tag string) (fieldTag, error) {
+	r := fieldTag{}
+	if tag == "" {
+		return r, nil
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		name, value, hasValue := strings.Cut(strings.TrimSpace(opt), "=")
+		switch {
+		case name == "sort" && (!hasValue || value == "asc" || value == "desc"):
+			r.sortable, r.descending = true, value == "desc"
+		case name == "nulls" && (value == "first" || value == "last"):
+			r.nullsFirst = value == "first"
+		case name == "filterable" && !hasValue:
+			r.filterable = true
+		case name == "ci" && !hasValue:
+			r.ci = true
+		default:
+			return r, fmt.Errorf("invalid %s tag option %q", TagName, opt)
+		}
+	}
+	return r, nil
+}
+
+// ComparatorFor returns a Comparator for values of type T (which should be a struct type, or pointer to struct type) that
+// compares by the specified field - according to the field's struct tag (see TagName)
+//
+// the comparator for each type and field is cached - so repeated calls have negligible overhead
+//
+// an error is returned if the field is unknown, has an invalid tag or is not tagged as sortable (ErrFieldNotSortable)
+func ComparatorFor[T any](field string) (Comparator[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	key := taggedKey{t: t, field: field}
+	if c, ok := taggedComparators.Load(key); ok {
+		return c.(Comparator[T]), nil
+	}
+	fi, err := taggedField(t, field)
+	if err != nil {
+		return nil, err
+	} else if !fi.tag.sortable {
+		return nil, fmt.Errorf("field %q: %w", field, ErrFieldNotSortable)
+	}
+	cmp, err := newFieldComparison(t, fi.field.Name, fi.tag.descending, fi.tag.nullsFirst, fi.tag.ci)
+	if err != nil {
+		return nil, err
+	}
+	c := NewComparator(func(v1, v2 T) int {
+		return cmp(reflect.ValueOf(&v1).Elem(), reflect.ValueOf(&v2).Elem())
+	})
+	actual, _ := taggedComparators.LoadOrStore(key, c)
+	return actual.(Comparator[T]), nil
+}
+
+// PredicateFor returns a Predicate for values of type T (which should be a struct type, or pointer to struct type) that
+// tests the specified field using the op and value - according to the field's struct tag (see TagName)
+//
+// ops are the same as for PredicateSpec (e.g. "==", "eq", "in", "like" etc.)
+//
+// an error is returned if the field is unknown, has an invalid tag or is not tagged as filterable (ErrFieldNotFilterable),
+// or the op is unknown or the value is incompatible with the field
+func PredicateFor[T any](field string, op string, value any) (Predicate[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	fi, err := taggedField(t, field)
+	if err != nil {
+		return nil, err
+	} else if !fi.tag.filterable {
+		return nil, fmt.Errorf("field %q: %w", field, ErrFieldNotFilterable)
+	}
+	cond, err := newCondition(t, fi.field.Name, op, value, fi.tag.ci)
+	if err != nil {
+		return nil, err
+	}
+	return conditionPredicate[T](cond), nil
+}
+
+type taggedKey struct {
+	t     reflect.Type
+	field string
+}
+
+var taggedComparators sync.Map
+
+func taggedField(t reflect.Type, field string) (*fieldInfo, error) {
+	st := derefType(t)
+	if st.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("type %s is not a struct", t)
+	}
+	fi, ok := fieldsOf(st).field(field)
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", field)
+	} else if fi.tagErr != nil {
+		return nil, fmt.Errorf("field %q: %w", field, fi.tagErr)
+	}
+	return fi, nil
+}
@@ -0,0 +1,114 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type taggedIssue struct {
+	Title    string  `streams:"sort,filterable,ci"`
+	Priority int     `streams:"sort=desc,filterable"`
+	Owner    *string `streams:"sort=asc,nulls=first"`
+	Status   string  `json:"status" streams:"filterable"`
+	Notes    string
+	Bad      string `streams:"sort=sideways"`
+}
+
+func TestParseFieldTag(t *testing.T) {
+	ft, err := parseFieldTag("")
+	require.NoError(t, err)
+	require.Equal(t, fieldTag{}, ft)
+	ft, err = parseFieldTag("sort=desc, nulls=first, filterable, ci")
+	require.NoError(t, err)
+	require.Equal(t, fieldTag{sortable: true, descending: true, nullsFirst: true, filterable: true, ci: true}, ft)
+	ft, err = parseFieldTag("sort,nulls=last")
+	require.NoError(t, err)
+	require.Equal(t, fieldTag{sortable: true}, ft)
+	for _, tag := range []string{"sort=up", "nulls", "filterable=true", "ci=1", "foo"} {
+		_, err = parseFieldTag(tag)
+		require.Error(t, err, tag)
+	}
+}
+
+func TestComparatorFor(t *testing.T) {
+	owner := func(s string) *string {
+		return &s
+	}
+	issues := Of(
+		taggedIssue{Title: "b", Priority: 1, Owner: owner("Sam")},
+		taggedIssue{Title: "C", Priority: 3},
+		taggedIssue{Title: "a", Priority: 2, Owner: owner("Bilbo")},
+	)
+	titles := func(s Stream[taggedIssue]) []string {
+		r := make([]string, 0)
+		_ = s.ForEach(NewConsumer(func(v taggedIssue) error {
+			r = append(r, v.Title)
+			return nil
+		}))
+		return r
+	}
+	c, err := ComparatorFor[taggedIssue]("Title")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "C"}, titles(issues.Sorted(c)))
+	c2, err := ComparatorFor[taggedIssue]("Title")
+	require.NoError(t, err)
+	require.True(t, sameComparator(c, c2))
+
+	c, err = ComparatorFor[taggedIssue]("priority")
+	require.NoError(t, err)
+	require.Equal(t, []string{"C", "a", "b"}, titles(issues.Sorted(c)))
+	c, err = ComparatorFor[taggedIssue]("Owner")
+	require.NoError(t, err)
+	require.Equal(t, []string{"C", "a", "b"}, titles(issues.Sorted(c)))
+	c, err = ComparatorFor[taggedIssue]("Owner")
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a", "C"}, titles(issues.Sorted(c.Reversed())))
+
+	pc, err := ComparatorFor[*taggedIssue]("Title")
+	require.NoError(t, err)
+	require.Equal(t, -1, pc.Compare(&taggedIssue{Title: "A"}, &taggedIssue{Title: "b"}))
+}
+
+func TestComparatorFor_Errors(t *testing.T) {
+	_, err := ComparatorFor[taggedIssue]("Status")
+	require.True(t, errors.Is(err, ErrFieldNotSortable))
+	require.EqualError(t, err, `field "Status": field is not sortable`)
+	_, err = ComparatorFor[taggedIssue]("Notes")
+	require.True(t, errors.Is(err, ErrFieldNotSortable))
+	_, err = ComparatorFor[taggedIssue]("Foo")
+	require.EqualError(t, err, `unknown field "Foo"`)
+	_, err = ComparatorFor[taggedIssue]("Bad")
+	require.EqualError(t, err, `field "Bad": invalid streams tag option "sort=sideways"`)
+	_, err = ComparatorFor[string]("Foo")
+	require.EqualError(t, err, `type string is not a struct`)
+}
+
+func TestPredicateFor(t *testing.T) {
+	p, err := PredicateFor[taggedIssue]("title", "==", "ABC")
+	require.NoError(t, err)
+	require.True(t, p.Test(taggedIssue{Title: "abc"}))
+	require.False(t, p.Test(taggedIssue{Title: "abd"}))
+	p, err = PredicateFor[taggedIssue]("title", "like", "a%")
+	require.NoError(t, err)
+	require.True(t, p.Test(taggedIssue{Title: "ABC"}))
+	p, err = PredicateFor[taggedIssue]("status", "in", []string{"open", "new"})
+	require.NoError(t, err)
+	require.True(t, p.Test(taggedIssue{Status: "new"}))
+	require.False(t, p.Test(taggedIssue{Status: "NEW"}))
+	p, err = PredicateFor[taggedIssue]("Priority", "gte", 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, Of(taggedIssue{Priority: 1}, taggedIssue{Priority: 2}).Count(p))
+}
+
+func TestPredicateFor_Errors(t *testing.T) {
+	_, err := PredicateFor[taggedIssue]("Owner", "==", "x")
+	require.True(t, errors.Is(err, ErrFieldNotFilterable))
+	require.EqualError(t, err, `field "Owner": field is not filterable`)
+	_, err = PredicateFor[taggedIssue]("Foo", "==", "x")
+	require.EqualError(t, err, `unknown field "Foo"`)
+	_, err = PredicateFor[taggedIssue]("Priority", "==", "x")
+	require.EqualError(t, err, `cannot compare field "Priority" (int) with "x"`)
+	_, err = PredicateFor[taggedIssue]("Priority", "~", 1)
+	require.EqualError(t, err, `unknown operator "~"`)
+}
@@ -2,7 +2,6 @@ package streams
 
 import (
 	"github.com/go-andiamo/gopt"
-	"sort"
 )
 
 // check that testStream implements Stream
@@ -81,15 +80,9 @@ func (s *testStream[T]) Difference(other Stream[T], c Comparator[T]) Stream[T] {
 }
 
 func (s *testStream[T]) Distinct() Stream[T] {
-	dvs := map[any]bool{}
-	r := &stream[T]{}
-	for _, v := range s.elements {
-		if !dvs[v] {
-			dvs[v] = true
-			r.elements = append(r.elements, v)
-		}
+	return &stream[T]{
+		elements: distinctElements(s.elements),
 	}
-	return r
 }
 
 func (s *testStream[T]) Filter(p Predicate[T]) Stream[T] {
@@ -278,6 +271,27 @@ func (s *testStream[T]) NthMatch(p Predicate[T], nth int) *gopt.Optional[T] {
 	return gopt.Empty[T]()
 }
 
+func (s *testStream[T]) Page(n int, size int) Page[T] {
+	return newPage(s.elements, n, size)
+}
+
+func (s *testStream[T]) Paginate(pageSize int) Pages[T] {
+	return newPages(s.elements, pageSize)
+}
+
+func (s *testStream[T]) Peek(c Consumer[T]) Stream[T] {
+	r := &stream[T]{
+		elements: make([]T, 0, len(s.elements)),
+	}
+	for _, v := range s.elements {
+		if c != nil {
+			_ = c.Accept(v)
+		}
+		r.elements = append(r.elements, v)
+	}
+	return r
+}
+
 func (s *testStream[T]) Reverse() Stream[T] {
 	l := len(s.elements)
 	r := &stream[T]{
@@ -316,18 +330,8 @@ func (s *testStream[T]) Skip(n int) Stream[T] {
 	}
 }
 
-func (s *testStream[T]) Sorted(c Comparator[T]) Stream[T] {
-	es := make([]T, 0, len(s.elements))
-	es = append(es, s.elements...)
-	r := &stream[T]{
-		elements: es,
-	}
-	if c != nil {
-		sort.Slice(r.elements, func(i, j int) bool {
-			return c.Less(r.elements[i], r.elements[j])
-		})
-	}
-	return r
+func (s *testStream[T]) Sorted(c Comparator[T]) SortedStream[T] {
+	return newSortedStream[T](s.elements, c)
 }
 
 func (s *testStream[T]) SymmetricDifference(other Stream[T], c Comparator[T]) Stream[T] {
@@ -348,10 +352,9 @@ func (s *testStream[T]) Union(other Stream[T], c Comparator[T]) Stream[T] {
 
 func (s *testStream[T]) Unique(c Comparator[T]) Stream[T] {
 	r := &stream[T]{}
-	var vt T
-	if isDistinctable(vt) {
+	if c == nil {
 		return s.Distinct()
-	} else if c != nil {
+	} else {
 		prevs := make(map[int]bool, len(s.elements))
 		for i, v := range s.elements {
 			if !prevs[i] {
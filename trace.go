@@ -0,0 +1,450 @@
+package streams
+
+import (
+	"github.com/go-andiamo/gopt"
+	"sync"
+	"time"
+)
+
+// Tracer is the interface used to receive trace events from a traced stream (see Traced)
+type Tracer interface {
+	// Trace is called with the event for each operation performed on a traced stream
+	Trace(event TraceEvent)
+}
+
+// TraceEvent is the event passed to a Tracer for each operation performed on a traced stream
+type TraceEvent struct {
+	// Operation is the name of the stream operation (e.g. "Filter", "Sorted", "ForEach")
+	Operation string
+	// Terminal is whether the operation is terminal (i.e. does not produce another stream)
+	Terminal bool
+	// InputCount is the number of elements in the stream the operation was performed on (or -1 if not known without
+	// pulling all elements of a lazy stream)
+	InputCount int
+	// OutputCount is the number of elements in the stream produced by the operation (or -1 for terminal operations, or if
+	// not known without pulling all elements of a lazy stream)
+	OutputCount int
+	// Duration is the time taken to perform the operation
+	Duration time.Duration
+	// Err is the error returned by the operation (only ForEach returns an error)
+	Err error
+}
+
+// NewTracer creates a new Tracer from the function provided
+func NewTracer(f TracerFunc) Tracer {
+	if f == nil {
+		return nil
+	}
+	return f
+}
+
+// TracerFunc is the function signature used to create a new Tracer
+type TracerFunc func(event TraceEvent)
+
+func (f TracerFunc) Trace(event TraceEvent) {
+	f(event)
+}
+
+var (
+	defaultTracer   Tracer
+	defaultTracerMu sync.RWMutex
+)
+
+// SetTracer sets the global default tracer - used by traced streams that were created without a tracer (see Traced)
+//
+// setting a nil tracer turns off tracing for such streams
+func SetTracer(t Tracer) {
+	defaultTracerMu.Lock()
+	defer defaultTracerMu.Unlock()
+	defaultTracer = t
+}
+
+func getDefaultTracer() Tracer {
+	defaultTracerMu.RLock()
+	defer defaultTracerMu.RUnlock()
+	return defaultTracer
+}
+
+// Traced creates a new traced stream around the supplied stream
+//
+// each operation performed on the traced stream (and on streams produced by its operations) sends an event to the
+// supplied tracer - if the supplied tracer is nil, events are sent to the global default tracer (see SetTracer)
+func Traced[T any](s Stream[T], t Tracer) Stream[T] {
+	return &tracedStream[T]{
+		inner:  s,
+		tracer: t,
+	}
+}
+
+type tracedStream[T any] struct {
+	inner  Stream[T]
+	tracer Tracer
+}
+
+func (s *tracedStream[T]) trace(operation string, start time.Time, result Stream[T], err error) {
+	t := s.tracer
+	if t == nil {
+		if t = getDefaultTracer(); t == nil {
+			return
+		}
+	}
+	event := TraceEvent{
+		Operation:   operation,
+		Terminal:    result == nil,
+		InputCount:  knownLen(s.inner),
+		OutputCount: -1,
+		Duration:    time.Since(start),
+		Err:         err,
+	}
+	if result != nil {
+		event.OutputCount = knownLen(result)
+	}
+	t.Trace(event)
+}
+
+// knownLen returns the length of the stream - unless determining the length would mean pulling all elements of a lazy stream
+func knownLen[T any](s Stream[T]) int {
+	if _, ok := s.(*lazyStream[T]); ok {
+		return -1
+	}
+	return s.Len()
+}
+
+func (s *tracedStream[T]) wrap(operation string, start time.Time, result Stream[T]) Stream[T] {
+	s.trace(operation, start, result, nil)
+	return &tracedStream[T]{
+		inner:  result,
+		tracer: s.tracer,
+	}
+}
+
+// AllMatch returns whether all elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *tracedStream[T]) AllMatch(p Predicate[T]) bool {
+	defer s.trace("AllMatch", time.Now(), nil, nil)
+	return s.inner.AllMatch(p)
+}
+
+// AnyMatch returns whether any elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *tracedStream[T]) AnyMatch(p Predicate[T]) bool {
+	defer s.trace("AnyMatch", time.Now(), nil, nil)
+	return s.inner.AnyMatch(p)
+}
+
+// Append creates a new stream with all the elements of this stream followed by the specified elements
+func (s *tracedStream[T]) Append(items ...T) Stream[T] {
+	start := time.Now()
+	return s.wrap("Append", start, s.inner.Append(items...))
+}
+
+// AsSlice returns the underlying slice
+func (s *tracedStream[T]) AsSlice() []T {
+	defer s.trace("AsSlice", time.Now(), nil, nil)
+	return s.inner.AsSlice()
+}
+
+// Concat creates a new stream with all the elements of this stream followed by all the elements of the added stream
+func (s *tracedStream[T]) Concat(add Stream[T]) Stream[T] {
+	start := time.Now()
+	return s.wrap("Concat", start, s.inner.Concat(add))
+}
+
+// Count returns the count of elements that match the provided predicate
+//
+// If the predicate is nil, returns the count of all elements
+func (s *tracedStream[T]) Count(p Predicate[T]) int {
+	defer s.trace("Count", time.Now(), nil, nil)
+	return s.inner.Count(p)
+}
+
+// Difference creates a new stream that is the set difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *tracedStream[T]) Difference(other Stream[T], c Comparator[T]) Stream[T] {
+	start := time.Now()
+	return s.wrap("Difference", start, s.inner.Difference(other, c))
+}
+
+// Distinct creates a new stream of distinct elements in this stream
+//
+// elements whose values are not hashable (e.g. slices, maps or funcs) are compared using reflect.DeepEqual
+func (s *tracedStream[T]) Distinct() Stream[T] {
+	start := time.Now()
+	return s.wrap("Distinct", start, s.inner.Distinct())
+}
+
+// Filter creates a new stream of elements in this stream that match the provided predicate
+//
+// if the provided predicate is nil, all elements in this stream are returned
+func (s *tracedStream[T]) Filter(p Predicate[T]) Stream[T] {
+	start := time.Now()
+	return s.wrap("Filter", start, s.inner.Filter(p))
+}
+
+// FirstMatch returns an optional of the first element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the first element in this stream is returned
+func (s *tracedStream[T]) FirstMatch(p Predicate[T]) *gopt.Optional[T] {
+	defer s.trace("FirstMatch", time.Now(), nil, nil)
+	return s.inner.FirstMatch(p)
+}
+
+// ForEach performs an action on each element of this stream
+//
+// the action to be performed is defined by the provided consumer
+//
+// if the provided consumer is nil, nothing is performed
+func (s *tracedStream[T]) ForEach(c Consumer[T]) (err error) {
+	start := time.Now()
+	defer func() {
+		s.trace("ForEach", start, nil, err)
+	}()
+	return s.inner.ForEach(c)
+}
+
+// Has returns whether this stream contains an element that is equal to the element value provided
+//
+// equality is determined using the provided comparator
+//
+// if the provided comparator is nil, always returns false
+func (s *tracedStream[T]) Has(v T, c Comparator[T]) bool {
+	defer s.trace("Has", time.Now(), nil, nil)
+	return s.inner.Has(v, c)
+}
+
+// Intersection creates a new stream that is the set intersection of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *tracedStream[T]) Intersection(other Stream[T], c Comparator[T]) Stream[T] {
+	start := time.Now()
+	return s.wrap("Intersection", start, s.inner.Intersection(other, c))
+}
+
+// Iterator returns an iterator (pull) function
+//
+// the iterator function can be used in for loops, for example
+//  next := strm.Iterator()
+//  for v, ok := next(); ok; v, ok = next() {
+//      fmt.Println(v)
+//  }
+//
+// Iterator can also optionally accept varargs of Predicate - which, if specified, are logically OR-ed on each pull to ensure
+// that pulled elements match
+func (s *tracedStream[T]) Iterator(ps ...Predicate[T]) func() (T, bool) {
+	defer s.trace("Iterator", time.Now(), nil, nil)
+	return s.inner.Iterator(ps...)
+}
+
+// LastMatch returns an optional of the last element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the last element in this stream is returned
+func (s *tracedStream[T]) LastMatch(p Predicate[T]) *gopt.Optional[T] {
+	defer s.trace("LastMatch", time.Now(), nil, nil)
+	return s.inner.LastMatch(p)
+}
+
+// Len returns the length (number of elements) of this stream
+func (s *tracedStream[T]) Len() int {
+	defer s.trace("Len", time.Now(), nil, nil)
+	return s.inner.Len()
+}
+
+// Limit creates a new stream whose number of elements is limited to the value provided
+//
+// if the maximum size is greater than the length of this stream, all elements are returned
+func (s *tracedStream[T]) Limit(maxSize int) Stream[T] {
+	start := time.Now()
+	return s.wrap("Limit", start, s.inner.Limit(maxSize))
+}
+
+// Max returns the maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *tracedStream[T]) Max(c Comparator[T]) *gopt.Optional[T] {
+	defer s.trace("Max", time.Now(), nil, nil)
+	return s.inner.Max(c)
+}
+
+// Min returns the minimum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *tracedStream[T]) Min(c Comparator[T]) *gopt.Optional[T] {
+	defer s.trace("Min", time.Now(), nil, nil)
+	return s.inner.Min(c)
+}
+
+// MinMax returns the minimum and maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned for both
+func (s *tracedStream[T]) MinMax(c Comparator[T]) (*gopt.Optional[T], *gopt.Optional[T]) {
+	defer s.trace("MinMax", time.Now(), nil, nil)
+	return s.inner.MinMax(c)
+}
+
+// NoneMatch returns whether none of the elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns true
+func (s *tracedStream[T]) NoneMatch(p Predicate[T]) bool {
+	defer s.trace("NoneMatch", time.Now(), nil, nil)
+	return s.inner.NoneMatch(p)
+}
+
+// NthMatch returns an optional of the nth matching element (1 based) according to the provided predicate
+//
+// if the nth argument is negative, the nth is taken as relative to the last
+//
+// if the provided predicate is nil, any element is taken as matching
+//
+// if no elements match in the specified position, an empty (not present) optional is returned
+func (s *tracedStream[T]) NthMatch(p Predicate[T], nth int) *gopt.Optional[T] {
+	defer s.trace("NthMatch", time.Now(), nil, nil)
+	return s.inner.NthMatch(p, nth)
+}
+
+// Page returns the nth page (1 based) of elements of this stream, where each page has the specified size - along with
+// page metadata (total elements, total pages and whether there are next/previous pages)
+//
+// if the page number is out of range or the size is less than 1, the page elements are empty
+func (s *tracedStream[T]) Page(n int, size int) Page[T] {
+	defer s.trace("Page", time.Now(), nil, nil)
+	return s.inner.Page(n, size)
+}
+
+// Paginate returns an iterator over the pages of this stream, where each page has the specified page size
+//
+// if the page size is less than 1, there are no pages
+func (s *tracedStream[T]) Paginate(pageSize int) Pages[T] {
+	defer s.trace("Paginate", time.Now(), nil, nil)
+	return s.inner.Paginate(pageSize)
+}
+
+// Peek creates a new stream with the same elements as this stream, additionally performing the provided action on each element
+//
+// Peek is intended for debugging - for example, to see elements as they flow through a chain of operations
+//
+// any error returned by the consumer is ignored, and if the provided consumer is nil, nothing is performed
+func (s *tracedStream[T]) Peek(c Consumer[T]) Stream[T] {
+	start := time.Now()
+	return s.wrap("Peek", start, s.inner.Peek(c))
+}
+
+// Reverse creates a new stream composed of elements from this stream but in reverse order
+func (s *tracedStream[T]) Reverse() Stream[T] {
+	start := time.Now()
+	return s.wrap("Reverse", start, s.inner.Reverse())
+}
+
+// Skip creates a new stream consisting of this stream after discarding the first n elements
+//
+// if the specified n to skip is equal to or greater than the number of elements in this stream,
+// an empty stream is returned
+func (s *tracedStream[T]) Skip(n int) Stream[T] {
+	start := time.Now()
+	return s.wrap("Skip", start, s.inner.Skip(n))
+}
+
+// Slice creates a new stream composed of elements from this stream starting at the specified start and including
+// the specified count (or to the end)
+//
+// the start is zero based (and less than zero is ignored)
+//
+// if the specified count is negative, items are selected from the start and then backwards by the count
+func (s *tracedStream[T]) Slice(start int, count int) Stream[T] {
+	st := time.Now()
+	return s.wrap("Slice", st, s.inner.Slice(start, count))
+}
+
+// Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
+//
+// if the provided comparator is nil, the elements are not sorted
+func (s *tracedStream[T]) Sorted(c Comparator[T]) SortedStream[T] {
+	start := time.Now()
+	r := s.inner.Sorted(c)
+	return &tracedSortedStream[T]{
+		tracedStream: s.wrap("Sorted", start, r).(*tracedStream[T]),
+		sorted:       r,
+	}
+}
+
+// SymmetricDifference creates a new stream that is the set symmetric difference between this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *tracedStream[T]) SymmetricDifference(other Stream[T], c Comparator[T]) Stream[T] {
+	start := time.Now()
+	return s.wrap("SymmetricDifference", start, s.inner.SymmetricDifference(other, c))
+}
+
+// Union creates a new stream that is the set union of this and the supplied other stream
+//
+// equality of elements is determined using the provided comparator (if the provided comparator is nil, the result is always empty)
+func (s *tracedStream[T]) Union(other Stream[T], c Comparator[T]) Stream[T] {
+	start := time.Now()
+	return s.wrap("Union", start, s.inner.Union(other, c))
+}
+
+// Unique creates a new stream of unique elements in this stream
+//
+// uniqueness is determined using the provided comparator
+//
+// if provided comparator is nil, Distinct is used as the result
+func (s *tracedStream[T]) Unique(c Comparator[T]) Stream[T] {
+	start := time.Now()
+	return s.wrap("Unique", start, s.inner.Unique(c))
+}
+
+// tracedSortedStream is the traced stream produced by tracedStream.Sorted
+type tracedSortedStream[T any] struct {
+	*tracedStream[T]
+	sorted SortedStream[T]
+}
+
+// Comparator returns the comparator by which this stream is sorted
+//
+// Note: the comparator is nil if the stream was sorted with a nil comparator (i.e. was not sorted)
+func (s *tracedSortedStream[T]) Comparator() Comparator[T] {
+	return s.sorted.Comparator()
+}
+
+// BinarySearch searches for the specified value and returns the index of the first element that is equal to it and true,
+// or, if there is no equal element, the index at which the value would be inserted and false
+//
+// if this stream has no comparator, always returns -1 and false
+func (s *tracedSortedStream[T]) BinarySearch(v T) (int, bool) {
+	defer s.trace("BinarySearch", time.Now(), nil, nil)
+	return s.sorted.BinarySearch(v)
+}
+
+// LowerBound returns the index of the first element that is not less than the specified value
+//
+// if this stream has no comparator, always returns -1
+func (s *tracedSortedStream[T]) LowerBound(v T) int {
+	defer s.trace("LowerBound", time.Now(), nil, nil)
+	return s.sorted.LowerBound(v)
+}
+
+// UpperBound returns the index of the first element that is greater than the specified value
+//
+// if this stream has no comparator, always returns -1
+func (s *tracedSortedStream[T]) UpperBound(v T) int {
+	defer s.trace("UpperBound", time.Now(), nil, nil)
+	return s.sorted.UpperBound(v)
+}
+
+// Range creates a new sorted stream of the elements that are greater than or equal to from and less than to
+//
+// if this stream has no comparator, the result is always empty
+func (s *tracedSortedStream[T]) Range(from, to T) SortedStream[T] {
+	start := time.Now()
+	r := s.sorted.Range(from, to)
+	return &tracedSortedStream[T]{
+		tracedStream: s.wrap("Range", start, r).(*tracedStream[T]),
+		sorted:       r,
+	}
+}
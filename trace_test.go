@@ -0,0 +1,146 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type testTracer struct {
+	events []TraceEvent
+}
+
+func (t *testTracer) Trace(event TraceEvent) {
+	t.events = append(t.events, event)
+}
+
+func (t *testTracer) operations() []string {
+	r := make([]string, len(t.events))
+	for i, e := range t.events {
+		r[i] = e.Operation
+	}
+	return r
+}
+
+func TestNewTracer(t *testing.T) {
+	called := false
+	tr := NewTracer(func(event TraceEvent) {
+		called = true
+	})
+	require.NotNil(t, tr)
+	tr.Trace(TraceEvent{})
+	require.True(t, called)
+
+	require.Nil(t, NewTracer(nil))
+}
+
+func TestTraced(t *testing.T) {
+	tr := &testTracer{}
+	s := Traced(Of(5, 1, 4, 2, 3, 2), tr)
+	isEven := NewPredicate(func(v int) bool {
+		return v%2 == 0
+	})
+	c := s.Filter(isEven).Sorted(IntComparator).Unique(IntComparator).Count(nil)
+	require.Equal(t, 2, c)
+	require.Equal(t, []string{"Filter", "Sorted", "Unique", "Count"}, tr.operations())
+	require.Equal(t, TraceEvent{Operation: "Filter", InputCount: 6, OutputCount: 3, Duration: tr.events[0].Duration}, tr.events[0])
+	require.Equal(t, 3, tr.events[1].InputCount)
+	require.Equal(t, 3, tr.events[1].OutputCount)
+	require.Equal(t, 3, tr.events[2].InputCount)
+	require.Equal(t, 2, tr.events[2].OutputCount)
+	require.True(t, tr.events[3].Terminal)
+	require.Equal(t, 2, tr.events[3].InputCount)
+	require.Equal(t, -1, tr.events[3].OutputCount)
+}
+
+func TestTraced_AllOperations(t *testing.T) {
+	tr := &testTracer{}
+	s := Traced(Of(5, 1, 4, 2, 3, 2), tr)
+	p := NewPredicate(func(v int) bool {
+		return v > 2
+	})
+	other := Of(1, 2)
+	s.AllMatch(p)
+	s.AnyMatch(p)
+	s.Append(6)
+	s.AsSlice()
+	s.Concat(other)
+	s.Count(p)
+	s.Difference(other, IntComparator)
+	s.Distinct()
+	s.Filter(p)
+	s.FirstMatch(p)
+	_ = s.ForEach(NewConsumer(func(v int) error {
+		return nil
+	}))
+	s.Has(1, IntComparator)
+	s.Intersection(other, IntComparator)
+	s.Iterator()
+	s.LastMatch(p)
+	s.Len()
+	s.Limit(2)
+	s.Max(IntComparator)
+	s.Min(IntComparator)
+	s.MinMax(IntComparator)
+	s.NoneMatch(p)
+	s.NthMatch(p, 1)
+	s.Page(1, 2)
+	s.Paginate(2)
+	s.Peek(nil)
+	s.Reverse()
+	s.Skip(1)
+	s.Slice(1, 2)
+	ss := s.Sorted(IntComparator)
+	s.SymmetricDifference(other, IntComparator)
+	s.Union(other, IntComparator)
+	s.Unique(nil)
+	require.Equal(t, []string{"AllMatch", "AnyMatch", "Append", "AsSlice", "Concat", "Count", "Difference", "Distinct",
+		"Filter", "FirstMatch", "ForEach", "Has", "Intersection", "Iterator", "LastMatch", "Len", "Limit", "Max", "Min",
+		"MinMax", "NoneMatch", "NthMatch", "Page", "Paginate", "Peek", "Reverse", "Skip", "Slice", "Sorted", "SymmetricDifference", "Union",
+		"Unique"}, tr.operations())
+
+	tr.events = nil
+	require.True(t, sameComparator(IntComparator, ss.Comparator()))
+	i, found := ss.BinarySearch(4)
+	require.True(t, found)
+	require.Equal(t, 4, i)
+	require.Equal(t, 3, ss.LowerBound(3))
+	require.Equal(t, 4, ss.UpperBound(3))
+	r := ss.Range(2, 4)
+	require.Equal(t, []int{2, 2, 3}, r.AsSlice())
+	require.Equal(t, []int{2, 2}, r.Range(2, 3).Range(0, 10).AsSlice())
+	require.Equal(t, []string{"BinarySearch", "LowerBound", "UpperBound", "Range", "AsSlice", "Range", "Range", "AsSlice"}, tr.operations())
+}
+
+func TestTraced_ForEachError(t *testing.T) {
+	tr := &testTracer{}
+	s := Traced(Of(1, 2, 3), tr)
+	err := s.ForEach(NewConsumer(func(v int) error {
+		return errors.New("fooey")
+	}))
+	require.Error(t, err)
+	require.Equal(t, 1, len(tr.events))
+	require.Equal(t, err, tr.events[0].Err)
+}
+
+func TestTraced_Lazy(t *testing.T) {
+	tr := &testTracer{}
+	ls, pulled := testLazyStream(1, 2, 3, 4)
+	s := Traced[int](ls, tr)
+	r := s.Limit(2).AsSlice()
+	require.Equal(t, []int{1, 2}, r)
+	require.Equal(t, 2, *pulled)
+	require.Equal(t, -1, tr.events[0].InputCount)
+	require.Equal(t, -1, tr.events[0].OutputCount)
+}
+
+func TestTraced_DefaultTracer(t *testing.T) {
+	defer SetTracer(nil)
+	s := Traced(Of(1, 2, 3), nil)
+	s.Len()
+	tr := &testTracer{}
+	SetTracer(tr)
+	s.Len()
+	s.Reverse().Len()
+	require.Equal(t, []string{"Len", "Reverse", "Len"}, tr.operations())
+}
@@ -0,0 +1,183 @@
+package streams
+
+import (
+	"fmt"
+)
+
+// DepthFirst creates a new stream of the nodes of a tree, traversed depth-first (pre-order) from the supplied root
+//
+// the children of each node are determined by the supplied children function (if the children function is nil, the
+// resulting stream contains just the root)
+//
+// DepthFirst performs no cycle detection - so should only be used for trees (for graphs that may contain cycles, use DepthFirstBy)
+//
+// the resulting stream is lazy - nodes are only visited as they are consumed
+func DepthFirst[T any](root T, children func(T) []T) Stream[T] {
+	return newLazyStream[T](depthFirstIterator[T, struct{}](root, children, nil))
+}
+
+// DepthFirstBy is the same as DepthFirst, except that nodes already visited (determined by the key returned from the
+// supplied key function) are not visited again - so is safe to use on graphs that contain cycles
+//
+// if the key function is nil, the resulting stream is empty
+func DepthFirstBy[T any, K comparable](root T, children func(T) []T, key func(T) K) Stream[T] {
+	if key == nil {
+		return &stream[T]{elements: make([]T, 0)}
+	}
+	return newLazyStream[T](depthFirstIterator[T, K](root, children, key))
+}
+
+// BreadthFirst creates a new stream of the nodes of a tree, traversed breadth-first (level order) from the supplied root
+//
+// the children of each node are determined by the supplied children function (if the children function is nil, the
+// resulting stream contains just the root)
+//
+// BreadthFirst performs no cycle detection - so should only be used for trees (for graphs that may contain cycles, use BreadthFirstBy)
+//
+// the resulting stream is lazy - nodes are only visited as they are consumed
+func BreadthFirst[T any](root T, children func(T) []T) Stream[T] {
+	return newLazyStream[T](breadthFirstIterator[T, struct{}](root, children, nil))
+}
+
+// BreadthFirstBy is the same as BreadthFirst, except that nodes already visited (determined by the key returned from the
+// supplied key function) are not visited again - so is safe to use on graphs that contain cycles
+//
+// if the key function is nil, the resulting stream is empty
+func BreadthFirstBy[T any, K comparable](root T, children func(T) []T, key func(T) K) Stream[T] {
+	if key == nil {
+		return &stream[T]{elements: make([]T, 0)}
+	}
+	return newLazyStream[T](breadthFirstIterator[T, K](root, children, key))
+}
+
+// TopologicalSort creates a new stream of the supplied nodes ordered such that every node appears after all of its
+// dependencies (as determined by the supplied deps function)
+//
+// dependencies that are not in the supplied nodes are also included in the result - and if the deps function is nil, nodes
+// are taken as having no dependencies
+//
+// where there is no dependency between nodes, the order of the supplied nodes is preserved as far as possible
+//
+// if the dependencies contain a cycle, a *CycleError is returned
+func TopologicalSort[T comparable](nodes Stream[T], deps func(T) []T) (Stream[T], error) {
+	return TopologicalSortBy[T, T](nodes, deps, func(v T) T {
+		return v
+	})
+}
+
+// TopologicalSortBy is the same as TopologicalSort, except that the identity of nodes is determined by the key returned
+// from the supplied key function
+//
+// if the nodes stream or key function is nil, the result is empty
+func TopologicalSortBy[T any, K comparable](nodes Stream[T], deps func(T) []T, key func(T) K) (Stream[T], error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	r := &stream[T]{elements: make([]T, 0)}
+	if nodes == nil || key == nil {
+		return r, nil
+	}
+	states := map[K]int{}
+	path := make([]T, 0)
+	var visit func(v T) error
+	visit = func(v T) error {
+		k := key(v)
+		switch states[k] {
+		case visited:
+			return nil
+		case visiting:
+			for i, pv := range path {
+				if key(pv) == k {
+					return &CycleError[T]{
+						Cycle: append(append(make([]T, 0, len(path)-i+1), path[i:]...), v),
+					}
+				}
+			}
+		}
+		states[k] = visiting
+		path = append(path, v)
+		if deps != nil {
+			for _, dv := range deps(v) {
+				if err := visit(dv); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		states[k] = visited
+		r.elements = append(r.elements, v)
+		return nil
+	}
+	if err := nodes.ForEach(NewConsumer(visit)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CycleError is the error returned by TopologicalSort (and TopologicalSortBy) when the dependencies contain a cycle
+type CycleError[T any] struct {
+	// Cycle is the path of nodes forming the cycle (the first and last nodes are the same)
+	Cycle []T
+}
+
+func (e *CycleError[T]) Error() string {
+	return fmt.Sprintf("cycle detected: %v", e.Cycle)
+}
+
+func depthFirstIterator[T any, K comparable](root T, children func(T) []T, key func(T) K) func() (T, bool) {
+	stack := []T{root}
+	seen := map[K]bool{}
+	return func() (T, bool) {
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if key != nil {
+				k := key(v)
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+			}
+			if children != nil {
+				// children pushed in reverse so that they are popped in order...
+				cs := children(v)
+				for i := len(cs) - 1; i >= 0; i-- {
+					stack = append(stack, cs[i])
+				}
+			}
+			return v, true
+		}
+		var r T
+		return r, false
+	}
+}
+
+func breadthFirstIterator[T any, K comparable](root T, children func(T) []T, key func(T) K) func() (T, bool) {
+	queue := []T{root}
+	seen := map[K]bool{}
+	if key != nil {
+		seen[key(root)] = true
+	}
+	return func() (T, bool) {
+		if len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			if children != nil {
+				for _, cv := range children(v) {
+					if key != nil {
+						k := key(cv)
+						if seen[k] {
+							continue
+						}
+						seen[k] = true
+					}
+					queue = append(queue, cv)
+				}
+			}
+			return v, true
+		}
+		var r T
+		return r, false
+	}
+}
@@ -0,0 +1,205 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+type testTreeNode struct {
+	name     string
+	children []*testTreeNode
+}
+
+func testTree() *testTreeNode {
+	return &testTreeNode{
+		name: "root",
+		children: []*testTreeNode{
+			{
+				name: "a",
+				children: []*testTreeNode{
+					{name: "a1"},
+					{name: "a2"},
+				},
+			},
+			{
+				name: "b",
+				children: []*testTreeNode{
+					{
+						name: "b1",
+						children: []*testTreeNode{
+							{name: "b1x"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testTreeChildren(n *testTreeNode) []*testTreeNode {
+	return n.children
+}
+
+func testTreeNames(s Stream[*testTreeNode]) []string {
+	r := make([]string, 0)
+	_ = s.ForEach(NewConsumer(func(v *testTreeNode) error {
+		r = append(r, v.name)
+		return nil
+	}))
+	return r
+}
+
+var testGraph = map[string][]string{
+	"a": {"b", "c"},
+	"b": {"d"},
+	"c": {"d", "a"},
+	"d": {"b"},
+}
+
+func testGraphChildren(v string) []string {
+	return testGraph[v]
+}
+
+func testGraphKey(v string) string {
+	return v
+}
+
+func TestDepthFirst(t *testing.T) {
+	s := DepthFirst(testTree(), testTreeChildren)
+	require.Equal(t, []string{"root", "a", "a1", "a2", "b", "b1", "b1x"}, testTreeNames(s))
+
+	s = DepthFirst(testTree(), nil)
+	require.Equal(t, []string{"root"}, testTreeNames(s))
+
+	count := s.Filter(NewPredicate(func(v *testTreeNode) bool {
+		return strings.HasPrefix(v.name, "b")
+	})).Len()
+	require.Equal(t, 0, count)
+	count = DepthFirst(testTree(), testTreeChildren).Filter(NewPredicate(func(v *testTreeNode) bool {
+		return strings.HasPrefix(v.name, "b")
+	})).Len()
+	require.Equal(t, 3, count)
+}
+
+func TestDepthFirst_Lazy(t *testing.T) {
+	visited := 0
+	s := DepthFirst(0, func(v int) []int {
+		visited++
+		// an infinite tree...
+		return []int{v*2 + 1, v*2 + 2}
+	})
+	require.Equal(t, []int{0, 1, 3, 7}, s.Limit(4).AsSlice())
+	require.Equal(t, 4, visited)
+}
+
+func TestDepthFirstBy(t *testing.T) {
+	s := DepthFirstBy("a", testGraphChildren, testGraphKey)
+	require.Equal(t, []string{"a", "b", "d", "c"}, s.AsSlice())
+	s = DepthFirstBy[string, string]("a", testGraphChildren, nil)
+	require.Equal(t, 0, s.Len())
+	s = DepthFirstBy("a", nil, testGraphKey)
+	require.Equal(t, []string{"a"}, s.AsSlice())
+}
+
+func TestBreadthFirst(t *testing.T) {
+	s := BreadthFirst(testTree(), testTreeChildren)
+	require.Equal(t, []string{"root", "a", "b", "a1", "a2", "b1", "b1x"}, testTreeNames(s))
+
+	s = BreadthFirst(testTree(), nil)
+	require.Equal(t, []string{"root"}, testTreeNames(s))
+}
+
+func TestBreadthFirst_Lazy(t *testing.T) {
+	visited := 0
+	s := BreadthFirst(0, func(v int) []int {
+		visited++
+		return []int{v*2 + 1, v*2 + 2}
+	})
+	require.Equal(t, []int{0, 1, 2, 3}, s.Limit(4).AsSlice())
+	require.Equal(t, 4, visited)
+}
+
+func TestBreadthFirstBy(t *testing.T) {
+	s := BreadthFirstBy("a", testGraphChildren, testGraphKey)
+	require.Equal(t, []string{"a", "b", "c", "d"}, s.AsSlice())
+	s = BreadthFirstBy[string, string]("a", testGraphChildren, nil)
+	require.Equal(t, 0, s.Len())
+	s = BreadthFirstBy("a", nil, testGraphKey)
+	require.Equal(t, []string{"a"}, s.AsSlice())
+}
+
+func TestTopologicalSort(t *testing.T) {
+	deps := map[string][]string{
+		"app":    {"lib", "log"},
+		"lib":    {"core", "log"},
+		"log":    {"core"},
+		"tools":  {"core"},
+		"core":   {},
+		"extern": {"other"},
+	}
+	s, err := TopologicalSort(Of("app", "tools", "extern"), func(v string) []string {
+		return deps[v]
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"core", "log", "lib", "app", "tools", "other", "extern"}, s.AsSlice())
+
+	s, err = TopologicalSort(Of("b", "a"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a"}, s.AsSlice())
+}
+
+func TestTopologicalSort_Cycle(t *testing.T) {
+	_, err := TopologicalSort(Of("a"), testGraphChildren)
+	require.Error(t, err)
+	var cerr *CycleError[string]
+	require.True(t, errors.As(err, &cerr))
+	require.Equal(t, []string{"b", "d", "b"}, cerr.Cycle)
+	require.Equal(t, "cycle detected: [b d b]", err.Error())
+}
+
+func TestTopologicalSortBy(t *testing.T) {
+	type task struct {
+		name string
+		deps []string
+	}
+	tasks := map[string]task{
+		"build":  {name: "build", deps: []string{"test"}},
+		"test":   {name: "test", deps: []string{"lint"}},
+		"lint":   {name: "lint"},
+		"deploy": {name: "deploy", deps: []string{"build"}},
+	}
+	depsOf := func(v task) []task {
+		r := make([]task, 0)
+		for _, d := range v.deps {
+			r = append(r, tasks[d])
+		}
+		return r
+	}
+	s, err := TopologicalSortBy(Of(tasks["deploy"], tasks["build"]), depsOf, func(v task) string {
+		return v.name
+	})
+	require.NoError(t, err)
+	names := make([]string, 0)
+	_ = s.ForEach(NewConsumer(func(v task) error {
+		names = append(names, v.name)
+		return nil
+	}))
+	require.Equal(t, []string{"lint", "test", "build", "deploy"}, names)
+}
+
+func TestTopologicalSortBy_NilArgs(t *testing.T) {
+	key := func(v string) string {
+		return v
+	}
+	s, err := TopologicalSortBy[string, string](Of("a", "b"), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, s.Len())
+	s, err = TopologicalSortBy(nil, nil, key)
+	require.NoError(t, err)
+	require.Equal(t, 0, s.Len())
+	s, err = TopologicalSortBy(Of("a", "b"), nil, key)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, s.AsSlice())
+}
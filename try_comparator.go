@@ -0,0 +1,59 @@
+package streams
+
+// TryComparator is the interface used to compare elements of a TryStream
+//
+// it differs from Comparator in that the comparison can fail
+type TryComparator[T any] interface {
+	// Compare compares the two values lexicographically, i.e.:
+	//
+	// * the result should be 0 if v1 == v2
+	//
+	// * the result should be -1 if v1 < v2
+	//
+	// * the result should be 1 if v1 > v2
+	Compare(v1, v2 T) (int, error)
+}
+
+// NewTryComparator creates a new TryComparator from the function provided
+func NewTryComparator[T any](f TryComparatorFunc[T]) TryComparator[T] {
+	if f == nil {
+		return nil
+	}
+	return tryComparator[T]{
+		f: f,
+	}
+}
+
+// AsTryComparator creates a new TryComparator from a Comparator (which never fails)
+func AsTryComparator[T any](c Comparator[T]) TryComparator[T] {
+	if c == nil {
+		return nil
+	}
+	return tryComparator[T]{
+		f: func(v1, v2 T) (int, error) {
+			return c.Compare(v1, v2), nil
+		},
+	}
+}
+
+type tryComparator[T any] struct {
+	f TryComparatorFunc[T]
+}
+
+// Compare compares the two values lexicographically, i.e.:
+//
+// * the result should be 0 if v1 == v2
+//
+// * the result should be -1 if v1 < v2
+//
+// * the result should be 1 if v1 > v2
+func (c tryComparator[T]) Compare(v1, v2 T) (int, error) {
+	return c.f(v1, v2)
+}
+
+// TryComparatorFunc is the function signature used to create a new TryComparator
+type TryComparatorFunc[T any] func(v1, v2 T) (int, error)
+
+func (f TryComparatorFunc[T]) Compare(v1, v2 T) (int, error) {
+	return f(v1, v2)
+}
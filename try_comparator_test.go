@@ -0,0 +1,44 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewTryComparator(t *testing.T) {
+	c := NewTryComparator(func(v1, v2 string) (int, error) {
+		if v1 == "" || v2 == "" {
+			return 0, errors.New("empty")
+		}
+		return StringComparator.Compare(v1, v2), nil
+	})
+	require.NotNil(t, c)
+	r, err := c.Compare("a", "b")
+	require.NoError(t, err)
+	require.Equal(t, -1, r)
+	_, err = c.Compare("a", "")
+	require.Error(t, err)
+
+	c = NewTryComparator[string](nil)
+	require.Nil(t, c)
+}
+
+func TestAsTryComparator(t *testing.T) {
+	c := AsTryComparator(StringComparator)
+	r, err := c.Compare("b", "a")
+	require.NoError(t, err)
+	require.Equal(t, 1, r)
+
+	c = AsTryComparator[string](nil)
+	require.Nil(t, c)
+}
+
+func TestTryComparatorFunc(t *testing.T) {
+	c := TryComparatorFunc[string](func(v1, v2 string) (int, error) {
+		return StringComparator.Compare(v1, v2), nil
+	})
+	r, err := c.Compare("a", "a")
+	require.NoError(t, err)
+	require.Equal(t, 0, r)
+}
@@ -0,0 +1,47 @@
+package streams
+
+// TryPredicate is the interface used by filtering and matching operations of a TryStream
+//
+// it differs from Predicate in that evaluating the predicate can fail
+type TryPredicate[T any] interface {
+	// Test evaluates this predicate against the supplied value
+	Test(v T) (bool, error)
+}
+
+// NewTryPredicate creates a new TryPredicate from the function provided
+func NewTryPredicate[T any](f TryPredicateFunc[T]) TryPredicate[T] {
+	if f == nil {
+		return nil
+	}
+	return tryPredicate[T]{
+		f: f,
+	}
+}
+
+// AsTryPredicate creates a new TryPredicate from a Predicate (which never fails)
+func AsTryPredicate[T any](p Predicate[T]) TryPredicate[T] {
+	if p == nil {
+		return nil
+	}
+	return tryPredicate[T]{
+		f: func(v T) (bool, error) {
+			return p.Test(v), nil
+		},
+	}
+}
+
+type tryPredicate[T any] struct {
+	f TryPredicateFunc[T]
+}
+
+// Test evaluates this predicate against the supplied value
+func (p tryPredicate[T]) Test(v T) (bool, error) {
+	return p.f(v)
+}
+
+// TryPredicateFunc is the function signature used to create a new TryPredicate
+type TryPredicateFunc[T any] func(v T) (bool, error)
+
+func (f TryPredicateFunc[T]) Test(v T) (bool, error) {
+	return f(v)
+}
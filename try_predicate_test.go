@@ -0,0 +1,49 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewTryPredicate(t *testing.T) {
+	p := NewTryPredicate(func(v string) (bool, error) {
+		if v == "" {
+			return false, errors.New("empty")
+		}
+		return v == "a", nil
+	})
+	require.NotNil(t, p)
+	ok, err := p.Test("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = p.Test("b")
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, err = p.Test("")
+	require.Error(t, err)
+
+	p = NewTryPredicate[string](nil)
+	require.Nil(t, p)
+}
+
+func TestAsTryPredicate(t *testing.T) {
+	p := AsTryPredicate(NewPredicate(func(v string) bool {
+		return v == "a"
+	}))
+	ok, err := p.Test("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	p = AsTryPredicate[string](nil)
+	require.Nil(t, p)
+}
+
+func TestTryPredicateFunc(t *testing.T) {
+	p := TryPredicateFunc[string](func(v string) (bool, error) {
+		return v == "a", nil
+	})
+	ok, err := p.Test("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
@@ -0,0 +1,435 @@
+package streams
+
+import (
+	"github.com/go-andiamo/gopt"
+	"sort"
+)
+
+// TryStream is an error-aware stream pipeline
+//
+// unlike Stream, the predicates and comparators used by a TryStream can fail (see TryPredicate and TryComparator) - and
+// errors are recorded by the stream rather than having to be captured in closures
+//
+// by default, the first error is recorded and all subsequent operations are short-circuited - i.e. once an error has been
+// recorded, the stream has no elements (so Filter, Limit, Skip and Sorted yield empty streams, Count yields 0 etc.) - but if
+// the CollectErrors option is used, failing elements are dropped and processing continues, with all errors being collected
+//
+// if the RecoverPanics option is used, panics in predicates, comparators, consumers and converters are recovered and
+// recorded as errors (see PanicError)
+//
+// terminal operations return a result and the recorded error(s)
+//
+// errors for failing elements are recorded as *ElementError - where the index is the position of the element in the stream
+// being processed (i.e. after any preceding operations, such as Filter or Skip), not its position in the original source (for
+// failing comparators, the element is the first of the two elements being compared)
+type TryStream[T any] interface {
+	// AllMatch returns whether all elements of this stream match the provided predicate
+	//
+	// if the provided predicate is nil or the stream is empty, always returns false
+	AllMatch(p TryPredicate[T]) (bool, error)
+	// AnyMatch returns whether any elements of this stream match the provided predicate
+	//
+	// if the provided predicate is nil or the stream is empty, always returns false
+	AnyMatch(p TryPredicate[T]) (bool, error)
+	// Count returns the count of elements that match the provided predicate
+	//
+	// If the predicate is nil, returns the count of all elements
+	Count(p TryPredicate[T]) (int, error)
+	// Err returns the error(s) recorded by this stream (or nil if there are no errors)
+	//
+	// if the CollectErrors option is used, the error is an Errors
+	Err() error
+	// Filter creates a new stream of elements in this stream that match the provided predicate
+	//
+	// if the provided predicate is nil, all elements in this stream are returned
+	Filter(p TryPredicate[T]) TryStream[T]
+	// FirstMatch returns an optional of the first element that matches the provided predicate
+	//
+	// if no elements match the provided predicate, an empty (not present) optional is returned
+	//
+	// if the provided predicate is nil, the first element in this stream is returned
+	FirstMatch(p TryPredicate[T]) (*gopt.Optional[T], error)
+	// ForEach performs an action on each element of this stream
+	//
+	// the action to be performed is defined by the provided consumer
+	//
+	// if the provided consumer is nil, nothing is performed
+	ForEach(c Consumer[T]) error
+	// Limit creates a new stream whose number of elements is limited to the value provided
+	//
+	// if the maximum size is greater than the length of this stream, all elements are returned
+	Limit(maxSize int) TryStream[T]
+	// Max returns the maximum element of this stream according to the provided comparator
+	//
+	// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+	Max(c TryComparator[T]) (*gopt.Optional[T], error)
+	// Min returns the minimum element of this stream according to the provided comparator
+	//
+	// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+	Min(c TryComparator[T]) (*gopt.Optional[T], error)
+	// Skip creates a new stream consisting of this stream after discarding the first n elements
+	//
+	// if the specified n to skip is equal to or greater than the number of elements in this stream,
+	// an empty stream is returned
+	Skip(n int) TryStream[T]
+	// Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
+	//
+	// if the provided comparator is nil, the elements are not sorted
+	Sorted(c TryComparator[T]) TryStream[T]
+	// Stream returns the elements of this stream as a Stream, along with the recorded error(s)
+	Stream() (Stream[T], error)
+}
+
+// TryOption is an option for a TryStream (options can be or-ed together)
+type TryOption uint
+
+const (
+	// CollectErrors is the TryStream option to collect all errors - elements that fail are dropped and processing continues
+	CollectErrors TryOption = 1 << iota
+	// RecoverPanics is the TryStream option to recover panics in predicates, comparators, consumers and converters - the
+	// recovered panics are recorded as errors (see PanicError)
+	RecoverPanics
+)
+
+// Try creates a new TryStream from the supplied stream
+func Try[T any](s Stream[T], options ...TryOption) TryStream[T] {
+	r := &tryStream[T]{}
+	for _, o := range options {
+		r.options |= o
+	}
+	if s != nil {
+		r.elements = append(r.elements, s.AsSlice()...)
+	}
+	return r
+}
+
+// TryOf creates a new TryStream of the values provided
+func TryOf[T any](values ...T) TryStream[T] {
+	return &tryStream[T]{
+		elements: values,
+	}
+}
+
+// TryMap converts the values in the input TryStream, using the provided Converter, and produces a TryStream of output types
+//
+// errors already recorded by the input stream are carried over to the output stream
+func TryMap[T any, R any](in TryStream[T], c Converter[T, R]) TryStream[R] {
+	ts := asTryStream(in)
+	errs := append(make([]error, 0, len(ts.errs)), ts.errs...)
+	if c == nil {
+		return newTryStream[R](nil, errs, ts.options)
+	}
+	if ts.options&RecoverPanics != 0 {
+		c = SafeConverter(c)
+	}
+	elements := make([]R, 0, len(ts.elements))
+	if ts.proceed() {
+		for i, v := range ts.elements {
+			if rv, err := c.Convert(v); err == nil {
+				elements = append(elements, rv)
+			} else {
+				errs = append(errs, &ElementError[T]{Index: i, Value: v, Err: err})
+				if ts.options&CollectErrors == 0 {
+					break
+				}
+			}
+		}
+	}
+	return newTryStream(elements, errs, ts.options)
+}
+
+type tryStream[T any] struct {
+	elements []T
+	errs     []error
+	options  TryOption
+}
+
+// newTryStream creates a new tryStream - where, if the stream is short-circuited (i.e. an error has been recorded and errors
+// are not being collected), the stream has no elements
+func newTryStream[T any](elements []T, errs []error, options TryOption) *tryStream[T] {
+	r := &tryStream[T]{
+		elements: elements,
+		errs:     errs,
+		options:  options,
+	}
+	if !r.proceed() {
+		r.elements = make([]T, 0)
+	}
+	return r
+}
+
+func asTryStream[T any](s TryStream[T]) *tryStream[T] {
+	if ts, ok := s.(*tryStream[T]); ok {
+		return ts
+	}
+	r := &tryStream[T]{}
+	if ss, err := s.Stream(); err == nil {
+		r.elements = ss.AsSlice()
+	} else {
+		r.errs = []error{err}
+	}
+	return r
+}
+
+// proceed determines whether processing should continue - i.e. there are no errors or all errors are being collected
+func (s *tryStream[T]) proceed() bool {
+	return len(s.errs) == 0 || s.options&CollectErrors != 0
+}
+
+func (s *tryStream[T]) with(elements []T, errs []error) *tryStream[T] {
+	return newTryStream(elements, errs, s.options)
+}
+
+// test evaluates the predicate against each element (in order) - calling the match function for each matching element
+//
+// the match function returns whether to continue testing subsequent elements
+func (s *tryStream[T]) test(p TryPredicate[T], match func(v T) bool) []error {
+	errs := append(make([]error, 0, len(s.errs)), s.errs...)
+	if s.options&RecoverPanics != 0 {
+		p = safeTryPredicate(p)
+	}
+	if s.proceed() {
+		for i, v := range s.elements {
+			if ok, err := p.Test(v); err != nil {
+				errs = append(errs, &ElementError[T]{Index: i, Value: v, Err: err})
+				if s.options&CollectErrors == 0 {
+					break
+				}
+			} else if ok && !match(v) {
+				break
+			}
+		}
+	}
+	return errs
+}
+
+func (s *tryStream[T]) err(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	} else if s.options&CollectErrors != 0 {
+		return Errors(errs)
+	}
+	return errs[0]
+}
+
+// AllMatch returns whether all elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *tryStream[T]) AllMatch(p TryPredicate[T]) (bool, error) {
+	if p == nil || len(s.elements) == 0 {
+		return false, s.Err()
+	}
+	all := true
+	errs := s.test(NewTryPredicate(func(v T) (bool, error) {
+		ok, err := p.Test(v)
+		return !ok, err
+	}), func(v T) bool {
+		all = false
+		return false
+	})
+	return all && (len(errs) == 0 || s.options&CollectErrors != 0), s.err(errs)
+}
+
+// AnyMatch returns whether any elements of this stream match the provided predicate
+//
+// if the provided predicate is nil or the stream is empty, always returns false
+func (s *tryStream[T]) AnyMatch(p TryPredicate[T]) (bool, error) {
+	if p == nil {
+		return false, s.Err()
+	}
+	found := false
+	errs := s.test(p, func(v T) bool {
+		found = true
+		return false
+	})
+	return found, s.err(errs)
+}
+
+// Count returns the count of elements that match the provided predicate
+//
+// If the predicate is nil, returns the count of all elements
+func (s *tryStream[T]) Count(p TryPredicate[T]) (int, error) {
+	if p == nil {
+		return len(s.elements), s.Err()
+	}
+	c := 0
+	errs := s.test(p, func(v T) bool {
+		c++
+		return true
+	})
+	return c, s.err(errs)
+}
+
+// Err returns the error(s) recorded by this stream (or nil if there are no errors)
+//
+// if the CollectErrors option is used, the error is an Errors
+func (s *tryStream[T]) Err() error {
+	return s.err(s.errs)
+}
+
+// Filter creates a new stream of elements in this stream that match the provided predicate
+//
+// if the provided predicate is nil, all elements in this stream are returned
+//
+// if an error has been recorded (and errors are not being collected), the resulting stream is empty
+func (s *tryStream[T]) Filter(p TryPredicate[T]) TryStream[T] {
+	if p == nil {
+		return s.with(s.elements, s.errs)
+	}
+	r := make([]T, 0)
+	errs := s.test(p, func(v T) bool {
+		r = append(r, v)
+		return true
+	})
+	return s.with(r, errs)
+}
+
+// FirstMatch returns an optional of the first element that matches the provided predicate
+//
+// if no elements match the provided predicate, an empty (not present) optional is returned
+//
+// if the provided predicate is nil, the first element in this stream is returned
+func (s *tryStream[T]) FirstMatch(p TryPredicate[T]) (*gopt.Optional[T], error) {
+	if p == nil {
+		if len(s.elements) > 0 && s.proceed() {
+			return gopt.Of[T](s.elements[0]), s.Err()
+		}
+		return gopt.Empty[T](), s.Err()
+	}
+	r := gopt.Empty[T]()
+	errs := s.test(p, func(v T) bool {
+		r = gopt.Of[T](v)
+		return false
+	})
+	return r, s.err(errs)
+}
+
+// ForEach performs an action on each element of this stream
+//
+// the action to be performed is defined by the provided consumer
+//
+// if the provided consumer is nil, nothing is performed
+func (s *tryStream[T]) ForEach(c Consumer[T]) error {
+	errs := append(make([]error, 0, len(s.errs)), s.errs...)
+	if c != nil && s.proceed() {
+		if s.options&RecoverPanics != 0 {
+			c = SafeConsumer(c)
+		}
+		for i, v := range s.elements {
+			if err := c.Accept(v); err != nil {
+				errs = append(errs, &ElementError[T]{Index: i, Value: v, Err: err})
+				if s.options&CollectErrors == 0 {
+					break
+				}
+			}
+		}
+	}
+	return s.err(errs)
+}
+
+// Limit creates a new stream whose number of elements is limited to the value provided
+//
+// if the maximum size is greater than the length of this stream, all elements are returned
+func (s *tryStream[T]) Limit(maxSize int) TryStream[T] {
+	max := absZero(maxSize)
+	if l := len(s.elements); l < max {
+		max = l
+	}
+	return s.with(s.elements[0:max], s.errs)
+}
+
+// Max returns the maximum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *tryStream[T]) Max(c TryComparator[T]) (*gopt.Optional[T], error) {
+	return s.best(c, 1)
+}
+
+// Min returns the minimum element of this stream according to the provided comparator
+//
+// if the provided comparator is nil or the stream is empty, an empty (not present) optional is returned
+func (s *tryStream[T]) Min(c TryComparator[T]) (*gopt.Optional[T], error) {
+	return s.best(c, -1)
+}
+
+func (s *tryStream[T]) best(c TryComparator[T], sign int) (*gopt.Optional[T], error) {
+	if c == nil || len(s.elements) == 0 || !s.proceed() {
+		return gopt.Empty[T](), s.Err()
+	}
+	errs := append(make([]error, 0, len(s.errs)), s.errs...)
+	if s.options&RecoverPanics != 0 {
+		c = safeTryComparator(c)
+	}
+	r := s.elements[0]
+	for i := 1; i < len(s.elements); i++ {
+		if cmp, err := c.Compare(s.elements[i], r); err != nil {
+			errs = append(errs, &ElementError[T]{Index: i, Value: s.elements[i], Err: err})
+			if s.options&CollectErrors == 0 {
+				return gopt.Empty[T](), s.err(errs)
+			}
+		} else if cmp*sign > 0 {
+			r = s.elements[i]
+		}
+	}
+	return gopt.Of(r), s.err(errs)
+}
+
+// Skip creates a new stream consisting of this stream after discarding the first n elements
+//
+// if the specified n to skip is equal to or greater than the number of elements in this stream,
+// an empty stream is returned
+func (s *tryStream[T]) Skip(n int) TryStream[T] {
+	skip := absZero(n)
+	if l := len(s.elements); skip >= l {
+		skip = l
+	}
+	return s.with(s.elements[skip:], s.errs)
+}
+
+// Sorted creates a new stream consisting of the elements of this stream, sorted according to the provided comparator
+//
+// if the provided comparator is nil, the elements are not sorted
+//
+// if the comparator fails, the first comparison error is recorded - and the resulting stream is empty (or, if errors are
+// being collected, the order of elements is undefined)
+func (s *tryStream[T]) Sorted(c TryComparator[T]) TryStream[T] {
+	if c == nil || !s.proceed() {
+		return s.with(s.elements, s.errs)
+	}
+	if s.options&RecoverPanics != 0 {
+		c = safeTryComparator(c)
+	}
+	// sort the indexes (rather than the elements) so that a failing comparison can be recorded against the element index...
+	idx := make([]int, len(s.elements))
+	for i := range idx {
+		idx[i] = i
+	}
+	var cerr error
+	sort.SliceStable(idx, func(i, j int) bool {
+		if cerr != nil {
+			return false
+		}
+		cmp, err := c.Compare(s.elements[idx[i]], s.elements[idx[j]])
+		if err != nil {
+			cerr = &ElementError[T]{Index: idx[i], Value: s.elements[idx[i]], Err: err}
+			return false
+		}
+		return cmp < 0
+	})
+	errs := s.errs
+	if cerr != nil {
+		errs = append(append(make([]error, 0, len(errs)+1), errs...), cerr)
+	}
+	es := make([]T, len(idx))
+	for i, x := range idx {
+		es[i] = s.elements[x]
+	}
+	return s.with(es, errs)
+}
+
+// Stream returns the elements of this stream as a Stream, along with the recorded error(s)
+func (s *tryStream[T]) Stream() (Stream[T], error) {
+	return &stream[T]{
+		elements: s.elements,
+	}, s.Err()
+}
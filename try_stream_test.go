@@ -0,0 +1,329 @@
+package streams
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+var errTestNegative = errors.New("negative")
+
+var tryIsEven = NewTryPredicate(func(v int) (bool, error) {
+	if v < 0 {
+		return false, errTestNegative
+	}
+	return v%2 == 0, nil
+})
+
+var tryIntComparator = NewTryComparator(func(v1, v2 int) (int, error) {
+	if v1 < 0 || v2 < 0 {
+		return 0, errTestNegative
+	}
+	return IntComparator.Compare(v1, v2), nil
+})
+
+func TestTry(t *testing.T) {
+	s := Try(Of(1, 2, 3))
+	r, err := s.Stream()
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, r.AsSlice())
+	require.NoError(t, s.Err())
+
+	s = Try[int](nil)
+	c, err := s.Count(nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, c)
+
+	s = TryOf(1, 2, 3)
+	c, err = s.Count(nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, c)
+}
+
+func TestTryStream_Filter(t *testing.T) {
+	s := TryOf(1, 2, 3, 4).Filter(tryIsEven)
+	r, err := s.Stream()
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 4}, r.AsSlice())
+
+	s = TryOf(1, 2, 3, 4).Filter(nil)
+	r, err = s.Stream()
+	require.NoError(t, err)
+	require.Equal(t, 4, r.Len())
+}
+
+func TestTryStream_Filter_FailFast(t *testing.T) {
+	called := 0
+	counting := NewTryPredicate(func(v int) (bool, error) {
+		called++
+		return true, nil
+	})
+	s := TryOf(2, -1, 4, -3).Filter(tryIsEven).Filter(counting)
+	err := s.Err()
+	require.Error(t, err)
+	require.True(t, errors.Is(err, errTestNegative))
+	var eerr *ElementError[int]
+	require.True(t, errors.As(err, &eerr))
+	require.Equal(t, 1, eerr.Index)
+	require.Equal(t, -1, eerr.Value)
+	require.Equal(t, 0, called)
+
+	// subsequent filters do not pass on elements once an error has been recorded...
+	c, err := s.Count(nil)
+	require.Error(t, err)
+	require.Equal(t, 0, c)
+	r, err := TryOf(2, -1, 4).Filter(tryIsEven).Filter(nil).Stream()
+	require.Error(t, err)
+	require.Equal(t, 0, r.Len())
+}
+
+func TestTryStream_Filter_CollectErrors(t *testing.T) {
+	s := Try(Of(2, -1, 4, -3), CollectErrors).Filter(tryIsEven)
+	r, err := s.Stream()
+	require.Error(t, err)
+	require.Equal(t, []int{2, 4}, r.AsSlice())
+	errs, ok := err.(Errors)
+	require.True(t, ok)
+	require.Equal(t, 2, len(errs))
+	require.Equal(t, "element [1]: negative\nelement [3]: negative", err.Error())
+}
+
+func TestTryStream_Matching(t *testing.T) {
+	s := TryOf(2, 4, 5)
+	ok, err := s.AllMatch(tryIsEven)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = s.Limit(2).AllMatch(tryIsEven)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s.AllMatch(nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = s.AnyMatch(tryIsEven)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s.AnyMatch(nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = s.Skip(2).AnyMatch(tryIsEven)
+	require.NoError(t, err)
+	require.False(t, ok)
+	o, err := s.FirstMatch(tryIsEven)
+	require.NoError(t, err)
+	require.Equal(t, 2, o.Default(0))
+	o, err = s.Skip(1).FirstMatch(nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, o.Default(0))
+	o, err = s.Skip(10).FirstMatch(nil)
+	require.NoError(t, err)
+	require.False(t, o.IsPresent())
+	c, err := s.Count(tryIsEven)
+	require.NoError(t, err)
+	require.Equal(t, 2, c)
+
+	s = TryOf(2, -4, 6)
+	ok, err = s.AllMatch(tryIsEven)
+	require.Error(t, err)
+	require.False(t, ok)
+	_, err = s.AnyMatch(tryIsEven)
+	require.NoError(t, err)
+	_, err = s.Count(tryIsEven)
+	require.Error(t, err)
+	o, err = s.Skip(1).FirstMatch(tryIsEven)
+	require.Error(t, err)
+	require.False(t, o.IsPresent())
+
+	s = Try(Of(2, -4, 6), CollectErrors)
+	ok, err = s.AllMatch(tryIsEven)
+	require.Error(t, err)
+	require.True(t, ok)
+	c, err = s.Count(tryIsEven)
+	require.Error(t, err)
+	require.Equal(t, 2, c)
+}
+
+func TestTryStream_ForEach(t *testing.T) {
+	collected := make([]int, 0)
+	consumer := NewConsumer(func(v int) error {
+		if v == 3 {
+			return errors.New("fooey")
+		}
+		collected = append(collected, v)
+		return nil
+	})
+	err := TryOf(1, 2, 3, 4).ForEach(consumer)
+	require.Error(t, err)
+	require.Equal(t, []int{1, 2}, collected)
+
+	collected = make([]int, 0)
+	err = Try(Of(1, 2, 3, 4), CollectErrors).ForEach(consumer)
+	require.Error(t, err)
+	require.Equal(t, []int{1, 2, 4}, collected)
+
+	collected = make([]int, 0)
+	err = TryOf(1, -2, 3).Filter(tryIsEven).ForEach(consumer)
+	require.Error(t, err)
+	require.Equal(t, 0, len(collected))
+
+	require.NoError(t, TryOf(1).ForEach(nil))
+}
+
+func TestTryStream_Sorted(t *testing.T) {
+	s := TryOf(3, 1, 2).Sorted(tryIntComparator)
+	r, err := s.Stream()
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, r.AsSlice())
+
+	s = TryOf(3, 1, 2).Sorted(nil)
+	r, err = s.Stream()
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 1, 2}, r.AsSlice())
+
+	s = TryOf(3, -1, 2).Sorted(tryIntComparator)
+	r, err = s.Stream()
+	require.Error(t, err)
+	require.True(t, errors.Is(err, errTestNegative))
+	var eerr *ElementError[int]
+	require.True(t, errors.As(err, &eerr))
+	require.Equal(t, []int{3, -1, 2}[eerr.Index], eerr.Value)
+	require.Equal(t, 0, r.Len())
+
+	s = Try(Of(3, -1, 2), CollectErrors).Sorted(tryIntComparator)
+	r, err = s.Stream()
+	require.Error(t, err)
+	require.True(t, errors.As(err, &eerr))
+	require.Equal(t, 3, r.Len())
+}
+
+func TestTryStream_ShortCircuited(t *testing.T) {
+	s := TryOf(2, -1, 4, 6, 8).Filter(tryIsEven)
+	require.Error(t, s.Err())
+	for _, ss := range []TryStream[int]{s, s.Limit(2), s.Skip(1), s.Sorted(tryIntComparator), s.Filter(nil)} {
+		r, err := ss.Stream()
+		require.Error(t, err)
+		require.Equal(t, 0, r.Len())
+		c, err := ss.Count(nil)
+		require.Error(t, err)
+		require.Equal(t, 0, c)
+		o, err := ss.FirstMatch(nil)
+		require.Error(t, err)
+		require.False(t, o.IsPresent())
+		o, err = ss.Max(tryIntComparator)
+		require.Error(t, err)
+		require.False(t, o.IsPresent())
+		ok, err := ss.AnyMatch(tryIsEven)
+		require.Error(t, err)
+		require.False(t, ok)
+	}
+}
+
+func TestTryStream_MinMax(t *testing.T) {
+	s := TryOf(3, 1, 2)
+	o, err := s.Min(tryIntComparator)
+	require.NoError(t, err)
+	require.Equal(t, 1, o.Default(0))
+	o, err = s.Max(tryIntComparator)
+	require.NoError(t, err)
+	require.Equal(t, 3, o.Default(0))
+	o, err = s.Max(nil)
+	require.NoError(t, err)
+	require.False(t, o.IsPresent())
+
+	s = TryOf(3, -1, 2)
+	o, err = s.Min(tryIntComparator)
+	require.Error(t, err)
+	require.False(t, o.IsPresent())
+
+	s = Try(Of(3, -1, 2, 4), CollectErrors)
+	o, err = s.Max(tryIntComparator)
+	require.Error(t, err)
+	require.Equal(t, 4, o.Default(0))
+}
+
+func TestTryMap(t *testing.T) {
+	atoi := NewConverter(func(v string) (int, error) {
+		return strconv.Atoi(v)
+	})
+	s := TryMap(TryOf("1", "2", "3"), atoi)
+	r, err := s.Stream()
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, r.AsSlice())
+
+	s = TryMap(TryOf("1", "x", "3", "y"), atoi)
+	r, err = s.Stream()
+	require.Error(t, err)
+	// short-circuited - so no elements...
+	require.Equal(t, []int{}, r.AsSlice())
+	var eerr *ElementError[string]
+	require.True(t, errors.As(err, &eerr))
+	require.Equal(t, "x", eerr.Value)
+
+	s = TryMap(Try(Of("1", "x", "3", "y"), CollectErrors), atoi)
+	r, err = s.Stream()
+	require.Error(t, err)
+	require.Equal(t, []int{1, 3}, r.AsSlice())
+	require.Equal(t, 2, len(err.(Errors)))
+
+	// errors carried over...
+	s = TryMap(TryOf("1", "-2").Filter(NewTryPredicate(func(v string) (bool, error) {
+		if v == "-2" {
+			return false, errTestNegative
+		}
+		return true, nil
+	})), atoi)
+	_, err = s.Stream()
+	require.True(t, errors.Is(err, errTestNegative))
+
+	s = TryMap[string, int](TryOf("1"), nil)
+	r, err = s.Stream()
+	require.NoError(t, err)
+	require.Equal(t, 0, r.Len())
+}
+
+func TestTryStream_RecoverPanics(t *testing.T) {
+	panicky := NewTryPredicate(func(v int) (bool, error) {
+		if v < 0 {
+			panic("negative")
+		}
+		return true, nil
+	})
+	s := Try(Of(1, -2, 3), RecoverPanics).Filter(panicky)
+	_, err := s.Stream()
+	require.Error(t, err)
+	var perr *PanicError[int]
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, []int{-2}, perr.Values)
+
+	s = Try(Of(1, -2, 3, -4), RecoverPanics|CollectErrors).Filter(panicky)
+	r, err := s.Stream()
+	require.Error(t, err)
+	require.Equal(t, []int{1, 3}, r.AsSlice())
+	require.Equal(t, 2, len(err.(Errors)))
+
+	require.Panics(t, func() {
+		_ = Try(Of(1, -2, 3)).Filter(panicky)
+	})
+
+	panickyComparator := NewTryComparator(func(v1, v2 int) (int, error) {
+		if v1 < 0 || v2 < 0 {
+			panic("negative")
+		}
+		return IntComparator.Compare(v1, v2), nil
+	})
+	s = Try(Of(3, -2, 1), RecoverPanics).Sorted(panickyComparator)
+	require.Error(t, s.Err())
+	_, err = Try(Of(3, -2, 1), RecoverPanics).Max(panickyComparator)
+	require.Error(t, err)
+
+	err = Try(Of(1, 2), RecoverPanics).ForEach(NewConsumer(func(v int) error {
+		panic("fooey")
+	}))
+	require.True(t, errors.As(err, &perr))
+
+	ms := TryMap(Try(Of("a", ""), RecoverPanics), NewConverter(func(v string) (byte, error) {
+		return v[0], nil
+	}))
+	var sperr *PanicError[string]
+	require.True(t, errors.As(ms.Err(), &sperr))
+}
@@ -1,7 +1,6 @@
 package streams
 
 import (
-	"reflect"
 	"strings"
 )
 
@@ -141,29 +140,31 @@ func absZero(n int) int {
 	return n
 }
 
-func isDistinctable(v any) bool {
-	switch v.(type) {
-	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, bool, float32, float64:
-		return true
+// removeIf removes (in place) all elements of the slice that match the predicate - returning the number removed
+func removeIf[T any](sl *[]T, p Predicate[T]) int {
+	es := *sl
+	n := 0
+	for _, v := range es {
+		if !p.Test(v) {
+			es[n] = v
+			n++
+		}
 	}
-	if reflect.TypeOf(v).Kind() != reflect.Ptr {
-		return true
+	var zero T
+	for i := n; i < len(es); i++ {
+		es[i] = zero
 	}
-	return false
+	*sl = es[:n]
+	return len(es) - n
 }
 
 func joinPredicates[T any](ps ...Predicate[T]) Predicate[T] {
-	var first Predicate[T]
-	for _, p := range ps {
-		if p != nil {
-			if first == nil {
-				first = p
-			} else {
-				first = first.Or(p)
-			}
-		}
+	if mp := newMultiPredicate[T](anyOf, ps, anyOf); len(mp.ps) > 1 {
+		return mp
+	} else if len(mp.ps) == 1 {
+		return mp.ps[0]
 	}
-	return first
+	return nil
 }
 
 // SliceIterator is a utility function that returns an iterator (pull) function on the specified slice
@@ -1,93 +1,11 @@
 package streams
 
 import (
-	"fmt"
 	"github.com/stretchr/testify/require"
 	"strings"
 	"testing"
 )
 
-func TestIsDistinctable(t *testing.T) {
-	testCases := []struct {
-		value  any
-		expect bool
-	}{
-		{
-			"",
-			true,
-		},
-		{
-			1,
-			true,
-		},
-		{
-			int8(1),
-			true,
-		},
-		{
-			int16(1),
-			true,
-		},
-		{
-			int32(1),
-			true,
-		},
-		{
-			int64(1),
-			true,
-		},
-		{
-			uint(1),
-			true,
-		},
-		{
-			uint8(1),
-			true,
-		},
-		{
-			uint16(1),
-			true,
-		},
-		{
-			uint32(1),
-			true,
-		},
-		{
-			uint64(1),
-			true,
-		},
-		{
-			true,
-			true,
-		},
-		{
-			float32(1.23),
-			true,
-		},
-		{
-			float64(1.23),
-			true,
-		},
-		{
-			struct{}{},
-			true,
-		},
-		{
-			&struct{}{},
-			false,
-		},
-		{
-			func() {},
-			true,
-		},
-	}
-	for i, tc := range testCases {
-		t.Run(fmt.Sprintf("[%d]", i+1), func(t *testing.T) {
-			require.Equal(t, tc.expect, isDistinctable(tc.value))
-		})
-	}
-}
-
 func TestAbsInt(t *testing.T) {
 	require.Equal(t, 0, absInt(0))
 	require.Equal(t, 1, absInt(1))